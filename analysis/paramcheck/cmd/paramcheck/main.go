@@ -0,0 +1,15 @@
+// The paramcheck command runs the paramcheck analyzer. It can be used
+// standalone or as a vet tool:
+//
+//	go vet -vettool=$(which paramcheck) ./...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/modfin/squildx/analysis/paramcheck"
+)
+
+func main() {
+	singlechecker.Main(paramcheck.Analyzer)
+}
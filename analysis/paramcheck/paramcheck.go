@@ -0,0 +1,156 @@
+// Package paramcheck defines an analyzer that reports mismatches between the
+// named placeholders in squildx SQL fragments and the keys of their Params.
+//
+// It inspects calls into the squildx package whose signature ends in
+// (sql string, params ...Params), such as Where, Having, OrderBy, Set, Values,
+// the join methods and OnConflictDoUpdate. A call is checked only when its SQL
+// is a constant and every params argument is a composite literal with constant
+// keys; anything else is left to the runtime checks in Build.
+//
+// The placeholders are found with the same scanning rules squildx uses at
+// runtime, so :: casts and @@ session variables are not reported.
+package paramcheck
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/modfin/squildx/internal/placeholder"
+)
+
+const squildxPath = "github.com/modfin/squildx"
+
+// Analyzer reports placeholder/params mismatches in squildx calls.
+var Analyzer = &analysis.Analyzer{
+	Name:     "paramcheck",
+	Doc:      "check that squildx SQL placeholders match the keys of their Params",
+	URL:      "https://pkg.go.dev/github.com/modfin/squildx/analysis/paramcheck",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	insp.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+		if !ok || fn.Pkg() == nil || fn.Pkg().Path() != squildxPath {
+			return
+		}
+		sqlIdx, ok := sqlParamIndex(fn)
+		if !ok || call.Ellipsis.IsValid() || len(call.Args) <= sqlIdx {
+			return
+		}
+
+		sqlArg := call.Args[sqlIdx]
+		tv := pass.TypesInfo.Types[sqlArg]
+		if tv.Value == nil || tv.Value.Kind() != constant.String {
+			return
+		}
+		sql := constant.StringVal(tv.Value)
+
+		keys, ok := literalKeys(pass, call.Args[sqlIdx+1:])
+		if !ok {
+			return
+		}
+		check(pass, fn.Name(), sqlArg, sql, keys)
+	})
+	return nil, nil
+}
+
+// sqlParamIndex reports the index of the SQL argument of fn, which must have a
+// string parameter immediately followed by a trailing ...Params.
+func sqlParamIndex(fn *types.Func) (int, bool) {
+	sig := fn.Type().(*types.Signature)
+	n := sig.Params().Len()
+	if !sig.Variadic() || n < 2 {
+		return 0, false
+	}
+	variadic, ok := sig.Params().At(n - 1).Type().(*types.Slice)
+	if !ok || !isParams(variadic.Elem()) {
+		return 0, false
+	}
+	if basic, ok := sig.Params().At(n - 2).Type().Underlying().(*types.Basic); !ok || basic.Kind() != types.String {
+		return 0, false
+	}
+	return n - 2, true
+}
+
+func isParams(t types.Type) bool {
+	named, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == squildxPath && obj.Name() == "Params"
+}
+
+// literalKeys collects the keys of the params arguments. It reports false when
+// any argument is not a composite literal with constant string keys.
+func literalKeys(pass *analysis.Pass, args []ast.Expr) (map[string]ast.Expr, bool) {
+	keys := make(map[string]ast.Expr)
+	for _, arg := range args {
+		lit, ok := ast.Unparen(arg).(*ast.CompositeLit)
+		if !ok {
+			return nil, false
+		}
+		for _, elt := range lit.Elts {
+			kv, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				return nil, false
+			}
+			tv := pass.TypesInfo.Types[kv.Key]
+			if tv.Value == nil || tv.Value.Kind() != constant.String {
+				return nil, false
+			}
+			keys[constant.StringVal(tv.Value)] = kv.Key
+		}
+	}
+	return keys, true
+}
+
+func check(pass *analysis.Pass, method string, sqlArg ast.Expr, sql string, keys map[string]ast.Expr) {
+	var prefix byte
+	seen := make(map[string]bool)
+	var missing []string
+	for _, ph := range placeholder.Scan(sql) {
+		if prefix == 0 {
+			prefix = ph.Prefix
+		}
+		if ph.Prefix != prefix {
+			pass.Reportf(sqlArg.Pos(), "%s: mixed parameter prefixes (: and @) in %q", method, sql)
+			return
+		}
+		if seen[ph.Name] {
+			continue
+		}
+		seen[ph.Name] = true
+		if _, ok := keys[ph.Name]; !ok {
+			missing = append(missing, ph.Name)
+		}
+	}
+
+	for _, name := range missing {
+		pass.Reportf(sqlArg.Pos(), "%s: placeholder %c%s has no matching key in params", method, prefix, name)
+	}
+
+	extra := make([]ast.Expr, 0, len(keys))
+	for name, key := range keys {
+		if !seen[name] {
+			extra = append(extra, key)
+		}
+	}
+	slices.SortFunc(extra, func(a, b ast.Expr) int { return int(a.Pos() - b.Pos()) })
+	for _, key := range extra {
+		name := constant.StringVal(pass.TypesInfo.Types[key].Value)
+		pass.Reportf(key.Pos(), "%s: params key %q has no matching placeholder", method, name)
+	}
+}
@@ -0,0 +1,13 @@
+package paramcheck_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/modfin/squildx/analysis/paramcheck"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), paramcheck.Analyzer, "a")
+}
@@ -0,0 +1,32 @@
+package a
+
+import "github.com/modfin/squildx"
+
+const byID = "id = :id"
+
+func checked() {
+	squildx.New().Where("id = :id", squildx.Params{"id": 1})
+	squildx.New().Where(byID, squildx.Params{"id": 1})
+	squildx.New().Where("a = :a AND b = :b", squildx.Params{"a": 1}, squildx.Params{"b": 2})
+	squildx.New().Where("created_at::date = :day", squildx.Params{"day": "2024-01-01"})
+	squildx.New().Where("@@session_var = 1")
+	squildx.New().Where("active")
+
+	squildx.New().Where("id = :id")                                           // want `Where: placeholder :id has no matching key in params`
+	squildx.New().Where("id = :id", squildx.Params{"uid": 1})                 // want `Where: placeholder :id has no matching key in params` `Where: params key "uid" has no matching placeholder`
+	squildx.New().OrderBy("name", squildx.Params{"name": 1})                  // want `OrderBy: params key "name" has no matching placeholder`
+	squildx.New().Where("a = :a AND b = @b", squildx.Params{"a": 1, "b": 2})  // want `Where: mixed parameter prefixes`
+	squildx.New().InnerJoin("orders o ON o.user_id = @uid")                   // want `InnerJoin: placeholder @uid has no matching key in params`
+	squildx.New().InnerJoinLateral(nil, "x", "x.id = :xid", squildx.Params{}) // want `InnerJoinLateral: placeholder :xid has no matching key in params`
+
+	squildx.NewInsert().Values(":id, :name", squildx.Params{"id": 1})          // want `Values: placeholder :name has no matching key in params`
+	squildx.NewInsert().OnConflictDoUpdate([]string{"id"}, "name = :n")        // want `OnConflictDoUpdate: placeholder :n has no matching key in params`
+	squildx.NewUpdate().Set("name = :name", squildx.Params{"name": 1, "x": 2}) // want `Set: params key "x" has no matching placeholder`
+}
+
+func unchecked(sql string, p squildx.Params, ps []squildx.Params) {
+	squildx.New().Where(sql, squildx.Params{"id": 1})
+	squildx.New().Where("id = :id", p)
+	squildx.New().Where("id = :id", ps...)
+	squildx.New().Limit(10)
+}
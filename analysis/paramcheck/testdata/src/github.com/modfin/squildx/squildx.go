@@ -0,0 +1,26 @@
+package squildx
+
+type Params map[string]any
+
+type Builder interface {
+	Where(sql string, params ...Params) Builder
+	OrderBy(expr string, params ...Params) Builder
+	InnerJoin(sql string, params ...Params) Builder
+	InnerJoinLateral(sub Builder, alias string, on string, params ...Params) Builder
+	Limit(n uint64) Builder
+}
+
+type InsertBuilder interface {
+	Values(sql string, params ...Params) InsertBuilder
+	OnConflictDoUpdate(columns []string, set string, params ...Params) InsertBuilder
+}
+
+type UpdateBuilder interface {
+	Set(sql string, params ...Params) UpdateBuilder
+}
+
+func New() Builder { return nil }
+
+func NewInsert() InsertBuilder { return nil }
+
+func NewUpdate() UpdateBuilder { return nil }
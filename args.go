@@ -0,0 +1,48 @@
+package squildx
+
+import (
+	"database/sql"
+	"maps"
+	"slices"
+
+	"github.com/modfin/squildx/internal/placeholder"
+)
+
+// BuildArgs builds the query for database/sql drivers that bind named
+// arguments natively, such as SQL Server's. Placeholders are rewritten to the
+// @name form and the params returned as sql.NamedArg values sorted by name:
+//
+//	q, args, err := b.BuildArgs()
+//	rows, err := db.QueryContext(ctx, q, args...)
+func (b *builder) BuildArgs() (string, []any, error) {
+	return namedArgs(b.Build())
+}
+
+func (b *insertBuilder) BuildArgs() (string, []any, error) {
+	return namedArgs(b.Build())
+}
+
+func (b *updateBuilder) BuildArgs() (string, []any, error) {
+	return namedArgs(b.Build())
+}
+
+func (b *deleteBuilder) BuildArgs() (string, []any, error) {
+	return namedArgs(b.Build())
+}
+
+func namedArgs(query string, params Params, err error) (string, []any, error) {
+	if err != nil {
+		return "", nil, err
+	}
+	if detectPrefix(query) == ':' {
+		query = placeholder.Rewrite(query, func(ph placeholder.Placeholder) string {
+			return "@" + ph.Name
+		})
+	}
+	names := slices.Sorted(maps.Keys(params))
+	args := make([]any, len(names))
+	for i, name := range names {
+		args[i] = sql.Named(name, params[name])
+	}
+	return query, args, nil
+}
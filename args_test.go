@@ -0,0 +1,88 @@
+package squildx
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestBuildArgs(t *testing.T) {
+	q, args, err := New().
+		Select("id").
+		From("users").
+		Where("name = :name", Params{"name": "Alice"}).
+		Where("age > :age AND created::date > :since::date", Params{"age": 18, "since": "2024-01-01"}).
+		BuildArgs()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "SELECT id FROM users WHERE name = @name AND age > @age AND created::date > @since::date"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+	want := []any{sql.Named("age", 18), sql.Named("name", "Alice"), sql.Named("since", "2024-01-01")}
+	if !reflect.DeepEqual(args, want) {
+		t.Errorf("args = %v, want %v", args, want)
+	}
+}
+
+func TestBuildArgs_AtPrefix(t *testing.T) {
+	q, args, err := NewDelete().
+		From("users").
+		Where("id = @id", Params{"id": 1}).
+		BuildArgs()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if q != "DELETE FROM users WHERE id = @id" {
+		t.Errorf("unexpected SQL: %s", q)
+	}
+	if !reflect.DeepEqual(args, []any{sql.Named("id", 1)}) {
+		t.Errorf("unexpected args: %v", args)
+	}
+}
+
+func TestBuildArgs_InsertAndUpdate(t *testing.T) {
+	q, args, err := NewInsert().
+		Into("users").
+		Columns("id", "name").
+		Values(":id, :name", Params{"id": 1, "name": "Alice"}).
+		BuildArgs()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if q != "INSERT INTO users (id, name) VALUES (@id, @name)" {
+		t.Errorf("unexpected SQL: %s", q)
+	}
+	if len(args) != 2 {
+		t.Errorf("expected 2 args, got %v", args)
+	}
+
+	q, _, err = NewUpdate().
+		Table("users").
+		SetValue("name", "Bob").
+		Where("id = :id", Params{"id": 1}).
+		BuildArgs()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if q != "UPDATE users SET name = @set_name_0 WHERE id = @id" {
+		t.Errorf("unexpected SQL: %s", q)
+	}
+}
+
+func TestBuildArgs_Error(t *testing.T) {
+	_, args, err := New().Select("id").BuildArgs()
+	if !errors.Is(err, ErrNoFrom) {
+		t.Errorf("expected ErrNoFrom, got: %v", err)
+	}
+	if args != nil {
+		t.Errorf("expected nil args, got %v", args)
+	}
+}
@@ -0,0 +1,119 @@
+package squildx
+
+import (
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// AuditPolicy names the audit columns that Build fills in when a query does
+// not set them explicitly. INSERT sets CreatedAt, UpdatedAt and CreatedBy;
+// UPDATE sets UpdatedAt and UpdatedBy. Empty column names are skipped, as are
+// the By columns while Actor is nil. INSERT ... SELECT is never audited.
+type AuditPolicy struct {
+	CreatedAt string
+	UpdatedAt string
+	CreatedBy string
+	UpdatedBy string
+
+	// Actor is bound to the By columns, e.g. the id of the current user.
+	Actor any
+
+	// Clock supplies the timestamp bound to the At columns. When nil they are
+	// set to now() in SQL instead.
+	Clock func() time.Time
+}
+
+var auditPolicy atomic.Pointer[AuditPolicy]
+
+// SetAuditPolicy sets the policy used by builders that have not been given one
+// with Audit. The zero AuditPolicy disables auditing.
+func SetAuditPolicy(p AuditPolicy) {
+	auditPolicy.Store(&p)
+}
+
+// Audit sets the policy used by this builder, e.g. to supply the Actor of the
+// current request. The zero AuditPolicy disables auditing.
+func (b *insertBuilder) Audit(p AuditPolicy) InsertBuilder {
+	cp := b.clone()
+	cp.audit = &p
+	return cp
+}
+
+func (b *updateBuilder) Audit(p AuditPolicy) UpdateBuilder {
+	cp := b.clone()
+	cp.audit = &p
+	return cp
+}
+
+// resolveAudit returns p, or the global policy when p is nil.
+func resolveAudit(p *AuditPolicy) AuditPolicy {
+	if p != nil {
+		return *p
+	}
+	if g := auditPolicy.Load(); g != nil {
+		return *g
+	}
+	return AuditPolicy{}
+}
+
+// auditValue is a column filled in by an audit policy and the SQL expression
+// it is set to.
+type auditValue struct {
+	column string
+	expr   string
+}
+
+// values returns the audit columns among timestamps and actors that are not in
+// set, binding their values in r. Clock is called at most once.
+func (p AuditPolicy) values(r *renderer, timestamps, actors []string, set []string) []auditValue {
+	var now any
+	var out []auditValue
+	for _, col := range timestamps {
+		if col == "" || slices.Contains(set, col) {
+			continue
+		}
+		if p.Clock == nil {
+			out = append(out, auditValue{column: col, expr: "now()"})
+			continue
+		}
+		if now == nil {
+			now = p.Clock()
+		}
+		out = append(out, bindAudit(r, col, now))
+	}
+	if p.Actor == nil {
+		return out
+	}
+	for _, col := range actors {
+		if col == "" || slices.Contains(set, col) {
+			continue
+		}
+		out = append(out, bindAudit(r, col, p.Actor))
+	}
+	return out
+}
+
+// bindAudit binds value to the audit_<column> parameter, using the prefix of
+// the query or : when it has no placeholders.
+func bindAudit(r *renderer, column string, value any) auditValue {
+	name := "audit_" + sanitizeParamName(column)
+	r.merge("Audit", 0, column, Params{name: value})
+	return auditValue{column: column, expr: string(r.bindPrefix()) + name}
+}
+
+// assignedColumns returns the columns assigned by SET clauses such as
+// "a = :a, b = now()".
+func assignedColumns(sets []paramClause) []string {
+	var cols []string
+	for _, s := range sets {
+		for _, assignment := range splitTopLevel(s.sql, ',') {
+			col, _, ok := strings.Cut(assignment, "=")
+			if ok {
+				cols = append(cols, strings.TrimSpace(col))
+			}
+		}
+	}
+	return cols
+}
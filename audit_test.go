@@ -0,0 +1,189 @@
+package squildx
+
+import (
+	"testing"
+	"time"
+)
+
+var auditTime = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+var testAudit = AuditPolicy{
+	CreatedAt: "created_at",
+	UpdatedAt: "updated_at",
+	CreatedBy: "created_by",
+	UpdatedBy: "updated_by",
+}
+
+func TestAudit_InsertNow(t *testing.T) {
+	q, params, err := NewInsert().
+		Into("users").
+		Columns("name").
+		Values(":name", Params{"name": "Alice"}).
+		Audit(testAudit).
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "INSERT INTO users (name, created_at, updated_at) VALUES (:name, now(), now())"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+	if len(params) != 1 {
+		t.Errorf("expected 1 param, got %d: %v", len(params), params)
+	}
+}
+
+func TestAudit_InsertClockAndActor(t *testing.T) {
+	p := testAudit
+	p.Actor = 42
+	p.Clock = func() time.Time { return auditTime }
+
+	q, params, err := NewInsert().
+		Into("users").
+		Columns("name", "created_at").
+		Values(":name, :created_at", Params{"name": "Alice", "created_at": time.Time{}}).
+		Values(":name2, :created_at", Params{"name2": "Bob", "created_at": time.Time{}}).
+		Audit(p).
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "INSERT INTO users (name, created_at, updated_at, created_by)" +
+		" VALUES (:name, :created_at, :audit_updated_at, :audit_created_by)," +
+		" (:name2, :created_at, :audit_updated_at, :audit_created_by)"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+	assertParam(t, params, "audit_updated_at", auditTime)
+	assertParam(t, params, "audit_created_by", 42)
+	assertParam(t, params, "created_at", time.Time{})
+}
+
+func TestAudit_InsertSelectNotAudited(t *testing.T) {
+	q, _, err := NewInsert().
+		Into("archive").
+		Columns("id").
+		Select(New().Select("id").From("users")).
+		Audit(testAudit).
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "INSERT INTO archive (id) SELECT id FROM users"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+}
+
+func TestAudit_Update(t *testing.T) {
+	p := testAudit
+	p.Actor = "alice"
+
+	q, params, err := NewUpdate().
+		Table("users").
+		Set("name = :name", Params{"name": "Bob"}).
+		Where("id = :id", Params{"id": 1}).
+		Audit(p).
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "UPDATE users SET name = :name, updated_at = now(), updated_by = :audit_updated_by WHERE id = :id"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+	assertParam(t, params, "audit_updated_by", "alice")
+}
+
+func TestAudit_UpdateSkipsExplicitColumns(t *testing.T) {
+	type User struct {
+		ID        int       `squildx:"id,pk"`
+		Name      string    `db:"name"`
+		UpdatedAt time.Time `db:"updated_at"`
+	}
+
+	q, _, err := NewUpdate().
+		Table("users").
+		SetObject(User{ID: 1, Name: "Bob", UpdatedAt: auditTime}).
+		Audit(testAudit).
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "UPDATE users SET name = :name, updated_at = :updated_at WHERE id = :id"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+}
+
+func TestAudit_AtPrefix(t *testing.T) {
+	p := testAudit
+	p.Clock = func() time.Time { return auditTime }
+
+	q, _, err := NewUpdate().
+		Table("users").
+		Set("name = @name", Params{"name": "Bob"}).
+		Where("id = @id", Params{"id": 1}).
+		Audit(p).
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "UPDATE users SET name = @name, updated_at = @audit_updated_at WHERE id = @id"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+}
+
+func TestSetAuditPolicy(t *testing.T) {
+	SetAuditPolicy(AuditPolicy{UpdatedAt: "updated_at"})
+	t.Cleanup(func() { SetAuditPolicy(AuditPolicy{}) })
+
+	base := NewUpdate().Table("users").Set("name = :name", Params{"name": "Bob"}).All()
+
+	q, _, err := base.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "UPDATE users SET name = :name, updated_at = now()"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+
+	q, _, err = base.Audit(AuditPolicy{}).Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected = "UPDATE users SET name = :name"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+}
+
+func TestAssignedColumns(t *testing.T) {
+	got := assignedColumns([]paramClause{
+		{sql: "a = :a, b = coalesce(:b, 1)"},
+		{sql: "tags = array_append(tags, :tag)"},
+	})
+	want := []string{"a", "b", "tags"}
+	if len(got) != len(want) {
+		t.Fatalf("assignedColumns = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("assignedColumns = %v, want %v", got, want)
+		}
+	}
+}
@@ -7,20 +7,23 @@ import (
 )
 
 func (b *builder) Build() (string, Params, error) {
-	if b.err != nil {
-		return "", nil, b.err
-	}
+	return b.build(nil)
+}
+
+// build renders the query. parent is the renderer of the query b is nested
+// in, or nil for a top-level query.
+func (b *builder) build(parent *renderer) (string, Params, error) {
+	r := newRenderer(b.paramPrefix, b.errs)
+	r.scopes = b.scoping.active(parent)
+	r.renamed = b.renamed
 
 	if len(b.columns) == 0 {
-		return "", nil, ErrNoColumns
+		r.fail(ErrNoColumns)
 	}
 	if b.from == "" {
-		return "", nil, ErrNoFrom
+		r.fail(ErrNoFrom)
 	}
 
-	params := make(Params)
-	prefix := b.paramPrefix
-
 	var sb strings.Builder
 
 	sb.WriteString("SELECT ")
@@ -32,21 +35,20 @@ func (b *builder) Build() (string, Params, error) {
 
 	sb.WriteString(" FROM ")
 	sb.WriteString(b.from)
+	r.merge("From", 0, b.from, b.fromParams)
+	var scopeConds []string
+	if b.namedQuery != "" && len(r.scopes) > 0 {
+		r.failClause("NamedQuery", 0, b.from, fmt.Errorf("%w: %s is scoped by %s", ErrScopedNamedQuery, b.namedQuery, r.scopeNames()))
+	} else {
+		scopeConds = r.scopeConditions("From", 0, b.from)
+	}
 
 	for _, j := range b.joins {
 		sb.WriteString(" ")
 		sb.WriteString(string(j.joinType))
 		sb.WriteString(" ")
 		if j.subQuery != nil {
-			subSQL, subParams, err := j.subQuery.Build()
-			if err != nil {
-				return "", nil, err
-			}
-			subPrefix := detectPrefix(subSQL)
-			prefix, err = reconcilePrefix(prefix, subPrefix)
-			if err != nil {
-				return "", nil, err
-			}
+			subSQL, _ := r.subquery(j.clause.method, j.clause.index, j.subQuery)
 			sb.WriteString("(")
 			sb.WriteString(subSQL)
 			sb.WriteString(") ")
@@ -55,46 +57,18 @@ func (b *builder) Build() (string, Params, error) {
 				sb.WriteString(" ON ")
 				sb.WriteString(j.clause.sql)
 			}
-			if err := mergeParams(params, subParams); err != nil {
-				return "", nil, err
-			}
-			if err := mergeParams(params, j.clause.params); err != nil {
-				return "", nil, err
-			}
+			r.merge(j.clause.method, j.clause.index, j.clause.sql, j.clause.params)
 			continue
 		}
-		sb.WriteString(j.clause.sql)
-		if err := mergeParams(params, j.clause.params); err != nil {
-			return "", nil, err
-		}
+		joinSQL, conds := r.scopedJoin(j.joinType, j.clause.index, j.clause.sql)
+		sb.WriteString(joinSQL)
+		scopeConds = append(scopeConds, conds...)
+		r.merge(j.clause.method, j.clause.index, j.clause.sql, j.clause.params)
 	}
 
-	if len(b.wheres) > 0 {
-		ands := make([]string, len(b.wheres))
-		for i, w := range b.wheres {
-			if w.subQuery != nil {
-				subSQL, subParams, err := w.subQuery.Build()
-				if err != nil {
-					return "", nil, err
-				}
-				subPrefix := detectPrefix(subSQL)
-				prefix, err = reconcilePrefix(prefix, subPrefix)
-				if err != nil {
-					return "", nil, err
-				}
-				ands[i] = fmt.Sprintf("%s (%s)", w.subPrefix, subSQL)
-				if err := mergeParams(params, subParams); err != nil {
-					return "", nil, err
-				}
-				continue
-			}
-			ands[i] = w.sql
-			if err := mergeParams(params, w.params); err != nil {
-				return "", nil, err
-			}
-		}
+	if len(b.wheres) > 0 || len(scopeConds) > 0 {
 		sb.WriteString(" WHERE ")
-		sb.WriteString(strings.Join(ands, " AND "))
+		sb.WriteString(scopedWhere(r.wheres(b.wheres), scopeConds))
 	}
 
 	if len(b.groupBys) > 0 {
@@ -104,14 +78,12 @@ func (b *builder) Build() (string, Params, error) {
 
 	if len(b.havings) > 0 {
 		if len(b.groupBys) == 0 {
-			return "", nil, ErrHavingWithoutGroupBy
+			r.fail(ErrHavingWithoutGroupBy)
 		}
 		ands := make([]string, len(b.havings))
 		for i, h := range b.havings {
 			ands[i] = h.sql
-			if err := mergeParams(params, h.params); err != nil {
-				return "", nil, err
-			}
+			r.merge(h.method, h.index, h.sql, h.params)
 		}
 		sb.WriteString(" HAVING ")
 		sb.WriteString(strings.Join(ands, " AND "))
@@ -121,9 +93,7 @@ func (b *builder) Build() (string, Params, error) {
 		exprs := make([]string, len(b.orderBys))
 		for i, o := range b.orderBys {
 			exprs[i] = o.sql
-			if err := mergeParams(params, o.params); err != nil {
-				return "", nil, err
-			}
+			r.merge(o.method, o.index, o.sql, o.params)
 		}
 		sb.WriteString(" ORDER BY ")
 		sb.WriteString(strings.Join(exprs, ", "))
@@ -139,5 +109,5 @@ func (b *builder) Build() (string, Params, error) {
 		sb.WriteString(strconv.FormatUint(*b.offset, 10))
 	}
 
-	return sb.String(), params, nil
+	return r.result(sb.String())
 }
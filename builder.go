@@ -1,5 +1,7 @@
 package squildx
 
+import "maps"
+
 // Params is a named parameter map for SQL query building.
 // It is interchangeable with map[string]any.
 type Params map[string]any
@@ -7,10 +9,12 @@ type Params map[string]any
 type Builder interface {
 	Select(columns ...string) Builder
 	SelectObject(obj any, table ...string) Builder
+	SelectObjectAs(obj any, table, prefix string) Builder
 	RemoveSelect(columns ...string) Builder
 	Distinct() Builder
 
 	From(table string) Builder
+	ReplaceFrom(table string, params ...Params) Builder
 
 	InnerJoin(sql string, params ...Params) Builder
 	LeftJoin(sql string, params ...Params) Builder
@@ -23,6 +27,8 @@ type Builder interface {
 	CrossJoinLateral(sub Builder, alias string) Builder
 
 	Where(sql string, params ...Params) Builder
+	WhereTagged(tag, sql string, params ...Params) Builder
+	RemoveWhere(tag string) Builder
 	WhereExists(sub Builder) Builder
 	WhereNotExists(sub Builder) Builder
 	WhereIn(column string, sub Builder) Builder
@@ -32,17 +38,34 @@ type Builder interface {
 	Having(sql string, params ...Params) Builder
 
 	OrderBy(expr string, params ...Params) Builder
+	ClearOrderBy() Builder
 
 	Limit(n uint64) Builder
+	ClearLimit() Builder
 	Offset(n uint64) Builder
 
+	Naming(ns NamingStrategy) Builder
+	Scoped(scopes ...Scope) Builder
+	Unscoped(names ...string) Builder
+
+	Columns() []string
+	FromTable() string
+	Joins() []Clause
+	Wheres() []Clause
+	OrderBys() []Clause
+	LimitValue() (uint64, bool)
+
 	Build() (string, Params, error)
+	BuildArgs() (string, []any, error)
+	Compile() (*Template, error)
 }
 
 type builder struct {
 	columns     []string
 	distinct    bool
 	from        string
+	fromParams  Params // params of a derived table in from
+	namedQuery  string // name of the NamedQuery in from, whose tables scopes cannot see
 	joins       []joinClause
 	wheres      []paramClause
 	groupBys    []string
@@ -50,8 +73,12 @@ type builder struct {
 	orderBys    []paramClause
 	limit       *uint64
 	offset      *uint64
-	paramPrefix byte // ':' or '@', 0 = not yet detected
-	err         error
+	naming      NamingStrategy    // nil = global strategy
+	scoping     scoping           // Scoped and Unscoped calls
+	renamed     map[string]string // renamed table -> original name, see RewriteTables
+	paramPrefix byte              // ':' or '@', 0 = not yet detected
+	failed      map[string]int    // failed calls per clause kind, see failAdd
+	errs        []error
 }
 
 func New() Builder {
@@ -69,6 +96,10 @@ func (b *builder) clone() *builder {
 	cp.groupBys = copySlice(b.groupBys)
 	cp.havings = copySlice(b.havings)
 	cp.orderBys = copySlice(b.orderBys)
+	cp.scoping = b.scoping.clone()
+	cp.renamed = maps.Clone(b.renamed)
+	cp.failed = maps.Clone(b.failed)
+	cp.errs = copySlice(b.errs)
 	return &cp
 }
 
@@ -76,9 +107,52 @@ func (b *builder) setPrefix(prefix byte) error {
 	return checkSetPrefix(&b.paramPrefix, prefix)
 }
 
+// resetPrefix recomputes paramPrefix from the clauses left after one was
+// removed or replaced, so that it no longer constrains later clauses.
+func (b *builder) resetPrefix() {
+	b.paramPrefix = 0
+	if b.fromParams != nil || b.namedQuery != "" {
+		b.paramPrefix = detectPrefix(b.from)
+	}
+	if b.paramPrefix == 0 {
+		joins := make([]paramClause, len(b.joins))
+		for i, j := range b.joins {
+			joins[i] = j.clause
+		}
+		b.paramPrefix = clausesPrefix(joins, b.wheres, b.havings, b.orderBys)
+	}
+}
+
+func (b *builder) fail(method string, index int, sql string, err error) {
+	b.errs = append(b.errs, &BuildError{Method: method, Index: index, SQL: sql, Err: err})
+}
+
+// callIndex returns the position of a call adding a clause of kind, when n
+// clauses of that kind have been added, counting failed calls too. Clauses
+// keep it so that errors found at build time are numbered like those found
+// when the clause is added.
+func (b *builder) callIndex(kind string, n int) int {
+	return n + b.failed[kind]
+}
+
+// failAdd records err for a call that failed to add a clause of kind, when n
+// clauses of that kind have been added. Failed calls are counted so that each
+// BuildError.Index is the position of its call.
+func (b *builder) failAdd(kind, method string, n int, sql string, err error) {
+	b.fail(method, b.callIndex(kind, n), sql, err)
+	if b.failed == nil {
+		b.failed = make(map[string]int)
+	}
+	b.failed[kind]++
+}
+
 type paramClause struct {
 	sql       string
 	params    Params
 	subQuery  Builder
 	subPrefix string
+	tag       string // set by WhereTagged
+	bind      string // param appended to sql with the query's prefix at build time
+	method    string // builder method that added the clause, for BuildError
+	index     int    // position of that call, see callIndex
 }
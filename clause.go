@@ -0,0 +1,138 @@
+package squildx
+
+import "maps"
+
+// Clause is a copy of a clause held by a builder, as returned by accessors
+// such as Wheres and Joins. Changing it does not affect the builder.
+type Clause struct {
+	// Kind is the join type of a join, such as "LEFT JOIN LATERAL", or what
+	// precedes the subquery of a WHERE clause, such as "EXISTS" or "id IN".
+	Kind   string
+	SQL    string // for a lateral join, its ON condition
+	Params Params
+	Sub    Builder // subquery of a lateral join or WHERE clause
+	Alias  string  // alias of a lateral join
+	Tag    string  // tag given to WhereTagged
+}
+
+func (c paramClause) export() Clause {
+	return Clause{
+		Kind:   c.subPrefix,
+		SQL:    c.sql,
+		Params: maps.Clone(c.params),
+		Sub:    c.subQuery,
+		Tag:    c.tag,
+	}
+}
+
+func exportClauses(cs []paramClause) []Clause {
+	if len(cs) == 0 {
+		return nil
+	}
+	out := make([]Clause, len(cs))
+	for i, c := range cs {
+		out[i] = c.export()
+	}
+	return out
+}
+
+// clausesPrefix returns the placeholder prefix of the first clause of cs
+// using one, or 0 when none does.
+func clausesPrefix(cs ...[]paramClause) byte {
+	for _, clauses := range cs {
+		for _, c := range clauses {
+			if p := detectPrefix(c.sql); p != 0 {
+				return p
+			}
+		}
+	}
+	return 0
+}
+
+// withoutTag returns the clauses of cs not tagged tag. An empty tag matches
+// no clause, so untagged clauses are never removed.
+func withoutTag(cs []paramClause, tag string) []paramClause {
+	if tag == "" {
+		return cs
+	}
+	var out []paramClause
+	for _, c := range cs {
+		if c.tag != tag {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// Columns returns the selected columns.
+func (b *builder) Columns() []string {
+	return copySlice(b.columns)
+}
+
+// FromTable returns the FROM clause.
+func (b *builder) FromTable() string {
+	return b.from
+}
+
+// Joins returns the joins in the order they were added.
+func (b *builder) Joins() []Clause {
+	if len(b.joins) == 0 {
+		return nil
+	}
+	out := make([]Clause, len(b.joins))
+	for i, j := range b.joins {
+		out[i] = j.clause.export()
+		out[i].Kind = string(j.joinType)
+		out[i].Sub = j.subQuery
+		out[i].Alias = j.alias
+	}
+	return out
+}
+
+// Wheres returns the WHERE clauses in the order they were added.
+func (b *builder) Wheres() []Clause {
+	return exportClauses(b.wheres)
+}
+
+// OrderBys returns the ORDER BY expressions in the order they were added.
+func (b *builder) OrderBys() []Clause {
+	return exportClauses(b.orderBys)
+}
+
+// LimitValue returns the LIMIT and whether one is set.
+func (b *builder) LimitValue() (uint64, bool) {
+	if b.limit == nil {
+		return 0, false
+	}
+	return *b.limit, true
+}
+
+// TableName returns the table set with Table.
+func (b *updateBuilder) TableName() string {
+	return b.table
+}
+
+// Wheres returns the WHERE clauses in the order they were added.
+func (b *updateBuilder) Wheres() []Clause {
+	return exportClauses(b.wheres)
+}
+
+// FromTable returns the table set with From.
+func (b *deleteBuilder) FromTable() string {
+	return b.table
+}
+
+// Wheres returns the WHERE clauses in the order they were added.
+func (b *deleteBuilder) Wheres() []Clause {
+	return exportClauses(b.wheres)
+}
+
+// TableName returns the table set with Into.
+func (b *insertBuilder) TableName() string {
+	return b.table
+}
+
+// ColumnNames returns the columns set with Columns or ColumnsObject.
+func (b *insertBuilder) ColumnNames() []string {
+	return copySlice(b.columns)
+}
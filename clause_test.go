@@ -0,0 +1,104 @@
+package squildx
+
+import "testing"
+
+func TestAccessors(t *testing.T) {
+	sub := New().Select("user_id").From("bans")
+	lateral := New().Select("total").From("orders o").Where("o.user_id = u.id")
+	b := New().
+		Select("u.id", "u.name").
+		From("users u").
+		LeftJoin("teams t ON t.id = u.team_id AND t.kind = :kind", Params{"kind": "dev"}).
+		LeftJoinLateral(lateral, "latest", "true").
+		Where("u.active = :active", Params{"active": true}).
+		WhereTagged("age", "u.age > :age", Params{"age": 18}).
+		WhereNotIn("u.id", sub).
+		OrderBy("u.name").
+		Limit(10)
+
+	if cols := b.Columns(); len(cols) != 2 || cols[0] != "u.id" || cols[1] != "u.name" {
+		t.Errorf("Columns() = %v", cols)
+	}
+	if from := b.FromTable(); from != "users u" {
+		t.Errorf("FromTable() = %q", from)
+	}
+
+	joins := b.Joins()
+	if len(joins) != 2 {
+		t.Fatalf("expected 2 joins, got %d", len(joins))
+	}
+	if joins[0].Kind != "LEFT JOIN" || joins[0].SQL != "teams t ON t.id = u.team_id AND t.kind = :kind" {
+		t.Errorf("unexpected first join: %+v", joins[0])
+	}
+	assertParam(t, joins[0].Params, "kind", "dev")
+	if joins[1].Kind != "LEFT JOIN LATERAL" || joins[1].Sub != lateral || joins[1].Alias != "latest" || joins[1].SQL != "true" {
+		t.Errorf("unexpected lateral join: %+v", joins[1])
+	}
+
+	wheres := b.Wheres()
+	if len(wheres) != 3 {
+		t.Fatalf("expected 3 wheres, got %d", len(wheres))
+	}
+	if wheres[0].SQL != "u.active = :active" || wheres[0].Tag != "" {
+		t.Errorf("unexpected first where: %+v", wheres[0])
+	}
+	if wheres[1].Tag != "age" {
+		t.Errorf("expected tag age, got %q", wheres[1].Tag)
+	}
+	if wheres[2].Kind != "u.id NOT IN" || wheres[2].Sub != sub {
+		t.Errorf("unexpected subquery where: %+v", wheres[2])
+	}
+
+	if obs := b.OrderBys(); len(obs) != 1 || obs[0].SQL != "u.name" {
+		t.Errorf("OrderBys() = %+v", obs)
+	}
+	if n, ok := b.LimitValue(); !ok || n != 10 {
+		t.Errorf("LimitValue() = %d, %v", n, ok)
+	}
+	if _, ok := New().LimitValue(); ok {
+		t.Error("expected no limit")
+	}
+}
+
+func TestAccessors_ReturnCopies(t *testing.T) {
+	b := New().Select("id").From("users").Where("id = :id", Params{"id": 1})
+
+	b.Columns()[0] = "name"
+	b.Wheres()[0].Params["id"] = 2
+
+	q, params, err := b.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "SELECT id FROM users WHERE id = :id"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+	assertParam(t, params, "id", 1)
+}
+
+func TestAccessors_OtherBuilders(t *testing.T) {
+	u := NewUpdate().Table("users").Set("name = :name", Params{"name": "A"}).WhereTagged("pk", "id = :id", Params{"id": 1})
+	if u.TableName() != "users" {
+		t.Errorf("TableName() = %q", u.TableName())
+	}
+	if w := u.Wheres(); len(w) != 1 || w[0].Tag != "pk" {
+		t.Errorf("Wheres() = %+v", w)
+	}
+
+	d := NewDelete().From("users").Where("id = :id", Params{"id": 1})
+	if d.FromTable() != "users" {
+		t.Errorf("FromTable() = %q", d.FromTable())
+	}
+	if w := d.Wheres(); len(w) != 1 || w[0].SQL != "id = :id" {
+		t.Errorf("Wheres() = %+v", w)
+	}
+
+	i := NewInsert().Into("users").Columns("id", "name")
+	if i.TableName() != "users" {
+		t.Errorf("TableName() = %q", i.TableName())
+	}
+	if cols := i.ColumnNames(); len(cols) != 2 || cols[1] != "name" {
+		t.Errorf("ColumnNames() = %v", cols)
+	}
+}
@@ -0,0 +1,76 @@
+package squildx
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// Template is a query built once by Compile, to be run many times with new
+// values. Binding only checks the values against the param names captured at
+// compile time and copies them over the compiled values, so it skips the
+// cloning, placeholder scanning and rendering of Build:
+//
+//	tpl, err := squildx.New().Select("id").From("users").
+//		Where("email = :email", squildx.Params{"email": ""}).
+//		Compile()
+//
+//	params, err := tpl.Bind(squildx.Params{"email": email})
+//	rows, err := db.NamedQueryContext(ctx, tpl.SQL(), params)
+//
+// A Template is immutable and safe for concurrent use.
+type Template struct {
+	sql    string
+	names  []string // sorted
+	params Params   // values bound at compile time
+}
+
+// Compile builds the query and captures its SQL and params in a Template.
+// It fails with the error Build would return.
+func (b *builder) Compile() (*Template, error) {
+	return compile(b.Build())
+}
+
+func (b *insertBuilder) Compile() (*Template, error) {
+	return compile(b.Build())
+}
+
+func (b *updateBuilder) Compile() (*Template, error) {
+	return compile(b.Build())
+}
+
+func (b *deleteBuilder) Compile() (*Template, error) {
+	return compile(b.Build())
+}
+
+func compile(sql string, params Params, err error) (*Template, error) {
+	if err != nil {
+		return nil, err
+	}
+	return &Template{sql: sql, names: slices.Sorted(maps.Keys(params)), params: params}, nil
+}
+
+// SQL returns the compiled query.
+func (t *Template) SQL() string {
+	return t.sql
+}
+
+// Names returns the names of the params of the query in sorted order.
+func (t *Template) Names() []string {
+	return slices.Clone(t.names)
+}
+
+// Bind returns the params bound at compile time, including those of scopes,
+// audit columns and SetValue, with the values in params in place of those of
+// the same name. It fails with ErrExtraParam when params has a name that is
+// not a param of the query.
+func (t *Template) Bind(params Params) (Params, error) {
+	for key := range params {
+		if _, found := slices.BinarySearch(t.names, key); !found {
+			return nil, fmt.Errorf("%w: %q", ErrExtraParam, key)
+		}
+	}
+	out := maps.Clone(t.params)
+	maps.Copy(out, params)
+	return out, nil
+}
@@ -0,0 +1,154 @@
+package squildx
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestCompile(t *testing.T) {
+	tpl, err := New().Select("id", "name").
+		From("users").
+		Where("tenant_id = :tenant_id", Params{"tenant_id": 0}).
+		Where("email = :email", Params{"email": ""}).
+		Limit(1).
+		Compile()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "SELECT id, name FROM users WHERE tenant_id = :tenant_id AND email = :email LIMIT 1"
+	if tpl.SQL() != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", tpl.SQL(), expected)
+	}
+	if names := tpl.Names(); !slices.Equal(names, []string{"email", "tenant_id"}) {
+		t.Errorf("Names() = %v", names)
+	}
+
+	params, err := tpl.Bind(Params{"tenant_id": 7, "email": "a@b.com"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertParam(t, params, "tenant_id", 7)
+	assertParam(t, params, "email", "a@b.com")
+}
+
+func TestCompile_BindErrors(t *testing.T) {
+	tpl, err := New().Select("id").From("users").Where("id = :id", Params{"id": 1}).Compile()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := tpl.Bind(Params{"uid": 1}); !errors.Is(err, ErrExtraParam) {
+		t.Errorf("expected ErrExtraParam, got: %v", err)
+	}
+	if _, err := tpl.Bind(Params{"id": 1, "name": "x"}); !errors.Is(err, ErrExtraParam) {
+		t.Errorf("expected ErrExtraParam, got: %v", err)
+	}
+}
+
+func TestCompile_BindKeepsCompiledValues(t *testing.T) {
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tenant := NewScope("tenant", "{table}.tenant_id = :tenant_id", Params{"tenant_id": 7})
+
+	tpl, err := NewUpdate().Table("users").
+		Scoped(tenant).
+		Audit(AuditPolicy{UpdatedAt: "updated_at", Clock: func() time.Time { return at }}).
+		SetValue("name", "").
+		Where("id = :id", Params{"id": 0}).
+		Compile()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	params, err := tpl.Bind(Params{"id": 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertParam(t, params, "id", 1)
+	assertParam(t, params, "tenant_id", 7)
+	assertParam(t, params, "set_name_0", "")
+	if len(params) != len(tpl.Names()) {
+		t.Errorf("Bind returned %v, want a value for each of %v", params, tpl.Names())
+	}
+
+	params, err = tpl.Bind(Params{"id": 2, "set_name_0": "Alice"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertParam(t, params, "id", 2)
+	assertParam(t, params, "set_name_0", "Alice")
+
+	if params, _ := tpl.Bind(nil); params["id"] != 0 {
+		t.Errorf("Bind changed the compiled values: id = %v", params["id"])
+	}
+}
+
+func TestCompile_NoParams(t *testing.T) {
+	tpl, err := NewDelete().From("sessions").All().Compile()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tpl.SQL() != "DELETE FROM sessions" {
+		t.Errorf("unexpected SQL: %s", tpl.SQL())
+	}
+	if _, err := tpl.Bind(nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestCompile_BuildError(t *testing.T) {
+	tpl, err := NewUpdate().Table("users").Set("name = :name", Params{"name": "x"}).Compile()
+	if !errors.Is(err, ErrUpdateNoWhere) {
+		t.Errorf("expected ErrUpdateNoWhere, got: %v", err)
+	}
+	if tpl != nil {
+		t.Error("expected no template on error")
+	}
+}
+
+func TestCompile_Insert(t *testing.T) {
+	tpl, err := NewInsert().Into("users").Columns("name").Values(":name", Params{"name": ""}).Compile()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "INSERT INTO users (name) VALUES (:name)"
+	if tpl.SQL() != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", tpl.SQL(), expected)
+	}
+}
+
+func benchQuery(email string) Builder {
+	return New().Select("u.id", "u.name", "o.total").
+		From("users u").
+		LeftJoin("orders o ON o.user_id = u.id AND o.status = :status", Params{"status": "paid"}).
+		Where("u.tenant_id = :tenant_id", Params{"tenant_id": 7}).
+		Where("u.email = :email", Params{"email": email}).
+		OrderBy("o.total DESC").
+		Limit(10)
+}
+
+func BenchmarkBuild(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, _, err := benchQuery("a@b.com").Build(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkTemplateBind(b *testing.B) {
+	tpl, err := benchQuery("").Compile()
+	if err != nil {
+		b.Fatal(err)
+	}
+	params := Params{"status": "paid", "tenant_id": 7, "email": "a@b.com"}
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := tpl.Bind(params); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
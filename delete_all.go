@@ -0,0 +1,9 @@
+package squildx
+
+// All marks the DELETE as intentionally affecting every row, bypassing the
+// ErrDeleteNoWhere guard. Combining All with a WHERE clause fails at Build.
+func (b *deleteBuilder) All() DeleteBuilder {
+	cp := b.clone()
+	cp.all = true
+	return cp
+}
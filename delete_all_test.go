@@ -0,0 +1,66 @@
+package squildx
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDeleteAll(t *testing.T) {
+	q, params, err := NewDelete().
+		From("sessions").
+		All().
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "DELETE FROM sessions"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+	if len(params) != 0 {
+		t.Errorf("expected 0 params, got %d", len(params))
+	}
+}
+
+func TestDeleteAll_WithReturning(t *testing.T) {
+	q, _, err := NewDelete().
+		From("sessions").
+		All().
+		Returning("id").
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "DELETE FROM sessions RETURNING id"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+}
+
+func TestDeleteAll_WithWhere(t *testing.T) {
+	sub := New().Select("user_id").From("banned_users")
+
+	_, _, err := NewDelete().
+		From("sessions").
+		WhereIn("user_id", sub).
+		All().
+		Build()
+
+	if !errors.Is(err, ErrDeleteAllWhere) {
+		t.Errorf("expected ErrDeleteAllWhere, got: %v", err)
+	}
+}
+
+func TestDeleteAll_Immutability(t *testing.T) {
+	base := NewDelete().From("sessions")
+	_ = base.All()
+
+	_, _, err := base.Build()
+	if !errors.Is(err, ErrDeleteNoWhere) {
+		t.Errorf("expected base to still require WHERE, got: %v", err)
+	}
+}
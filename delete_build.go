@@ -1,60 +1,49 @@
 package squildx
 
-import (
-	"fmt"
-	"strings"
-)
+import "strings"
 
 func (b *deleteBuilder) Build() (string, Params, error) {
-	if b.err != nil {
-		return "", nil, b.err
-	}
+	r := newRenderer(b.paramPrefix, b.errs)
+	r.scopes = b.scoping.active(nil)
+	r.renamed = b.renamed
 
 	if b.table == "" {
-		return "", nil, ErrDeleteNoTable
+		r.fail(ErrDeleteNoTable)
 	}
-	if len(b.wheres) == 0 {
-		return "", nil, ErrDeleteNoWhere
+	switch {
+	case b.all && len(b.wheres) > 0:
+		r.fail(ErrDeleteAllWhere)
+	case !b.all && len(b.wheres) == 0:
+		r.fail(ErrDeleteNoWhere)
 	}
 
-	params := make(Params)
-	prefix := b.paramPrefix
-
 	var sb strings.Builder
 
-	sb.WriteString("DELETE FROM ")
-	sb.WriteString(b.table)
-
-	ands := make([]string, len(b.wheres))
-	for i, w := range b.wheres {
-		if w.subQuery != nil {
-			subSQL, subParams, err := w.subQuery.Build()
-			if err != nil {
-				return "", nil, err
-			}
-			subPrefix := detectPrefix(subSQL)
-			prefix, err = reconcilePrefix(prefix, subPrefix)
-			if err != nil {
-				return "", nil, err
-			}
-			ands[i] = fmt.Sprintf("%s (%s)", w.subPrefix, subSQL)
-			if err := mergeParams(params, subParams); err != nil {
-				return "", nil, err
-			}
-			continue
-		}
-		ands[i] = w.sql
-		if err := mergeParams(params, w.params); err != nil {
-			return "", nil, err
-		}
+	softColumn := b.softDeleteColumn()
+	if softColumn != "" {
+		sb.WriteString("UPDATE ")
+		sb.WriteString(b.table)
+		sb.WriteString(" SET ")
+		sb.WriteString(softColumn)
+		sb.WriteString(" = now()")
+	} else {
+		sb.WriteString("DELETE FROM ")
+		sb.WriteString(b.table)
+	}
+
+	conds := r.scopeConditions("From", 0, b.table)
+	if softColumn != "" {
+		conds = append([]string{softColumn + " IS NULL"}, conds...)
+	}
+	if len(b.wheres) > 0 || len(conds) > 0 {
+		sb.WriteString(" WHERE ")
+		sb.WriteString(scopedWhere(r.wheres(b.wheres), conds))
 	}
-	sb.WriteString(" WHERE ")
-	sb.WriteString(strings.Join(ands, " AND "))
 
 	if len(b.returnings) > 0 {
 		sb.WriteString(" RETURNING ")
 		sb.WriteString(strings.Join(b.returnings, ", "))
 	}
 
-	return sb.String(), params, nil
+	return r.result(sb.String())
 }
@@ -1,24 +1,45 @@
 package squildx
 
+import "maps"
+
 // DeleteBuilder provides a fluent, immutable API for constructing DELETE queries.
 type DeleteBuilder interface {
 	From(table string) DeleteBuilder
 	Where(sql string, params ...Params) DeleteBuilder
+	WhereTagged(tag, sql string, params ...Params) DeleteBuilder
+	RemoveWhere(tag string) DeleteBuilder
 	WhereExists(sub Builder) DeleteBuilder
 	WhereNotExists(sub Builder) DeleteBuilder
 	WhereIn(column string, sub Builder) DeleteBuilder
 	WhereNotIn(column string, sub Builder) DeleteBuilder
+	All() DeleteBuilder
+	Soft(column string) DeleteBuilder
+	HardDelete() DeleteBuilder
 	Returning(columns ...string) DeleteBuilder
 	ReturningObject(obj any) DeleteBuilder
+	Naming(ns NamingStrategy) DeleteBuilder
+	Scoped(scopes ...Scope) DeleteBuilder
+	Unscoped(names ...string) DeleteBuilder
+	FromTable() string
+	Wheres() []Clause
 	Build() (string, Params, error)
+	BuildArgs() (string, []any, error)
+	Compile() (*Template, error)
 }
 
 type deleteBuilder struct {
 	table       string
 	wheres      []paramClause
 	returnings  []string
+	all         bool
+	softColumn  string
+	hard        bool
+	naming      NamingStrategy    // nil = global strategy
+	scoping     scoping           // Scoped and Unscoped calls
+	renamed     map[string]string // renamed table -> original name, see RewriteTables
 	paramPrefix byte
-	err         error
+	failed      map[string]int // failed calls per clause kind, see failAdd
+	errs        []error
 }
 
 func NewDelete() DeleteBuilder {
@@ -29,9 +50,39 @@ func (b *deleteBuilder) clone() *deleteBuilder {
 	cp := *b
 	cp.wheres = copySlice(b.wheres)
 	cp.returnings = copySlice(b.returnings)
+	cp.scoping = b.scoping.clone()
+	cp.renamed = maps.Clone(b.renamed)
+	cp.failed = maps.Clone(b.failed)
+	cp.errs = copySlice(b.errs)
 	return &cp
 }
 
 func (b *deleteBuilder) setPrefix(prefix byte) error {
 	return checkSetPrefix(&b.paramPrefix, prefix)
 }
+
+// resetPrefix recomputes paramPrefix from the remaining clauses, as
+// builder.resetPrefix does.
+func (b *deleteBuilder) resetPrefix() {
+	b.paramPrefix = clausesPrefix(b.wheres)
+}
+
+func (b *deleteBuilder) fail(method string, index int, sql string, err error) {
+	b.errs = append(b.errs, &BuildError{Method: method, Index: index, SQL: sql, Err: err})
+}
+
+// callIndex returns the position of a call adding a clause of kind, as
+// builder.callIndex does.
+func (b *deleteBuilder) callIndex(kind string, n int) int {
+	return n + b.failed[kind]
+}
+
+// failAdd records err for a call that failed to add a clause of kind, as
+// builder.failAdd does.
+func (b *deleteBuilder) failAdd(kind, method string, n int, sql string, err error) {
+	b.fail(method, b.callIndex(kind, n), sql, err)
+	if b.failed == nil {
+		b.failed = make(map[string]int)
+	}
+	b.failed[kind]++
+}
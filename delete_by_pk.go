@@ -0,0 +1,21 @@
+package squildx
+
+// DeleteByPK returns a DELETE from table matching the row by the fields of obj
+// tagged pk:
+//
+//	DeleteByPK("users", u) // DELETE FROM users WHERE id = :id
+//
+// Composite keys add one WHERE clause per pk field. Building fails with
+// ErrNoPrimaryKey when obj has no pk field or one of them is a nil pointer.
+// Untagged fields are named by the global NamingStrategy.
+func DeleteByPK(table string, obj any) DeleteBuilder {
+	b := &deleteBuilder{table: table}
+	keys, err := structKeys(obj, b.naming)
+	if err != nil {
+		b.fail("DeleteByPK", 0, "", err)
+		return b
+	}
+	b.paramPrefix = ':'
+	b.wheres = keys
+	return b
+}
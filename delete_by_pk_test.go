@@ -0,0 +1,82 @@
+package squildx
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDeleteByPK(t *testing.T) {
+	type User struct {
+		ID   int    `squildx:"id,pk"`
+		Name string `db:"name"`
+	}
+
+	q, params, err := DeleteByPK("users", User{ID: 1, Name: "Alice"}).Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "DELETE FROM users WHERE id = :id"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+	assertParam(t, params, "id", 1)
+	if len(params) != 1 {
+		t.Errorf("expected 1 param, got %d: %v", len(params), params)
+	}
+}
+
+func TestDeleteByPK_CompositeKey(t *testing.T) {
+	type Membership struct {
+		OrgID  int    `squildx:"org_id,pk"`
+		UserID int    `squildx:"user_id,pk"`
+		Role   string `db:"role"`
+	}
+
+	q, params, err := DeleteByPK("memberships", &Membership{OrgID: 7, UserID: 3}).
+		Where("role <> :role", Params{"role": "owner"}).
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "DELETE FROM memberships WHERE org_id = :org_id AND user_id = :user_id AND role <> :role"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+	assertParam(t, params, "org_id", 7)
+	assertParam(t, params, "user_id", 3)
+	assertParam(t, params, "role", "owner")
+}
+
+func TestDeleteByPK_NoPrimaryKey(t *testing.T) {
+	type User struct {
+		ID int `db:"id"`
+	}
+
+	_, _, err := DeleteByPK("users", User{ID: 1}).Build()
+
+	if !errors.Is(err, ErrNoPrimaryKey) {
+		t.Errorf("expected ErrNoPrimaryKey, got: %v", err)
+	}
+	var be *BuildError
+	if !errors.As(err, &be) || be.Method != "DeleteByPK" {
+		t.Errorf("expected DeleteByPK BuildError, got: %v", err)
+	}
+}
+
+func TestDeleteByPK_MixedPrefix(t *testing.T) {
+	type User struct {
+		ID int `squildx:"id,pk"`
+	}
+
+	_, _, err := DeleteByPK("users", User{ID: 1}).
+		Where("name = @name", Params{"name": "Alice"}).
+		Build()
+
+	if !errors.Is(err, ErrMixedPrefix) {
+		t.Errorf("expected ErrMixedPrefix, got: %v", err)
+	}
+}
@@ -8,9 +8,9 @@ func (b *deleteBuilder) Returning(columns ...string) DeleteBuilder {
 
 func (b *deleteBuilder) ReturningObject(obj any) DeleteBuilder {
 	cp := b.clone()
-	cols, err := structColumns(obj, "")
+	cols, err := structColumns(obj, "", optNested, cp.naming)
 	if err != nil {
-		cp.err = err
+		cp.failAdd("returnings", "ReturningObject", len(cp.returnings), "", err)
 		return cp
 	}
 	cp.returnings = append(cp.returnings, cols...)
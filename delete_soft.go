@@ -0,0 +1,84 @@
+package squildx
+
+import (
+	"maps"
+	"sync"
+)
+
+// Soft turns the DELETE into an UPDATE that marks rows as deleted instead of
+// removing them:
+//
+//	UPDATE users SET deleted_at = now() WHERE ... AND deleted_at IS NULL
+//
+// WHERE subqueries, scopes and Returning render as they would for the DELETE.
+func (b *deleteBuilder) Soft(column string) DeleteBuilder {
+	cp := b.clone()
+	cp.softColumn = column
+	cp.hard = false
+	return cp
+}
+
+// HardDelete renders a real DELETE even if Soft was called or the table was
+// registered with RegisterSoftDelete.
+func (b *deleteBuilder) HardDelete() DeleteBuilder {
+	cp := b.clone()
+	cp.hard = true
+	return cp
+}
+
+var softDeletes struct {
+	sync.RWMutex
+	columns map[string]string
+}
+
+// RegisterSoftDelete makes every DELETE from table a soft delete setting
+// column, as if Soft(column) had been called. Tables match as they do for
+// Scope.ForTables: a schema-qualified table such as public.users matches
+// either its full or its bare name, regardless of case and identifier quotes.
+// Passing an empty column unregisters the table.
+func RegisterSoftDelete(table, column string) {
+	softDeletes.Lock()
+	defer softDeletes.Unlock()
+	columns := maps.Clone(softDeletes.columns)
+	if columns == nil {
+		columns = make(map[string]string)
+	}
+	if column == "" {
+		delete(columns, table)
+	} else {
+		columns[table] = column
+	}
+	softDeletes.columns = columns
+}
+
+// softDeleteColumn returns the column a DELETE from b.table sets instead of
+// deleting rows, or "" for a hard delete.
+func (b *deleteBuilder) softDeleteColumn() string {
+	if b.hard {
+		return ""
+	}
+	if b.softColumn != "" {
+		return b.softColumn
+	}
+	table, _, ok := parseTableRef(b.table)
+	if !ok {
+		return ""
+	}
+	if orig, ok := b.renamed[table]; ok {
+		table = orig
+	}
+	softDeletes.RLock()
+	defer softDeletes.RUnlock()
+	// A registration of the full name wins over one of the bare name.
+	for registered, column := range softDeletes.columns {
+		if normalizeTable(registered) == normalizeTable(table) {
+			return column
+		}
+	}
+	for registered, column := range softDeletes.columns {
+		if tableMatches(table, registered) {
+			return column
+		}
+	}
+	return ""
+}
@@ -0,0 +1,161 @@
+package squildx
+
+import "testing"
+
+func TestDeleteSoft(t *testing.T) {
+	q, params, err := NewDelete().
+		From("users").
+		Where("id = :id", Params{"id": 1}).
+		Soft("deleted_at").
+		Returning("id").
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "UPDATE users SET deleted_at = now() WHERE id = :id AND deleted_at IS NULL RETURNING id"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+	assertParam(t, params, "id", 1)
+}
+
+func TestDeleteSoft_Subquery(t *testing.T) {
+	sub := New().Select("user_id").From("bans")
+
+	q, _, err := NewDelete().
+		From("users").
+		WhereIn("id", sub).
+		Where("role = 'guest' OR role = 'trial'").
+		Soft("deleted_at").
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "UPDATE users SET deleted_at = now() WHERE (id IN (SELECT user_id FROM bans) AND role = 'guest' OR role = 'trial') AND deleted_at IS NULL"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+}
+
+func TestDeleteSoft_All(t *testing.T) {
+	q, _, err := NewDelete().From("sessions").All().Soft("revoked_at").Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "UPDATE sessions SET revoked_at = now() WHERE revoked_at IS NULL"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+}
+
+func TestDeleteSoft_Scoped(t *testing.T) {
+	q, _, err := NewDelete().
+		From("users").
+		Where("id = :id", Params{"id": 1}).
+		Soft("deleted_at").
+		Scoped(tenantScope(7)).
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "UPDATE users SET deleted_at = now() WHERE id = :id AND deleted_at IS NULL AND users.tenant_id = :tenant_id"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+}
+
+func TestDeleteHardDelete(t *testing.T) {
+	q, _, err := NewDelete().
+		From("users").
+		Where("id = :id", Params{"id": 1}).
+		Soft("deleted_at").
+		HardDelete().
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "DELETE FROM users WHERE id = :id"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+}
+
+func TestRegisterSoftDelete(t *testing.T) {
+	RegisterSoftDelete("users", "deleted_at")
+	t.Cleanup(func() { RegisterSoftDelete("users", "") })
+
+	q, _, err := NewDelete().From("users u").Where("u.id = :id", Params{"id": 1}).Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "UPDATE users u SET deleted_at = now() WHERE u.id = :id AND deleted_at IS NULL"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+
+	q, _, err = NewDelete().From("orders").Where("id = :id", Params{"id": 1}).Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected = "DELETE FROM orders WHERE id = :id"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+
+	q, _, err = NewDelete().From("users").Where("id = :id", Params{"id": 1}).HardDelete().Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected = "DELETE FROM users WHERE id = :id"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+}
+
+func TestRegisterSoftDelete_TableMatching(t *testing.T) {
+	RegisterSoftDelete("users", "deleted_at")
+	RegisterSoftDelete("archive.users", "archived_at")
+	t.Cleanup(func() {
+		RegisterSoftDelete("users", "")
+		RegisterSoftDelete("archive.users", "")
+	})
+
+	tests := map[string]string{
+		"public.users":       "UPDATE public.users SET deleted_at = now() WHERE id = 1 AND deleted_at IS NULL",
+		"Users":              "UPDATE Users SET deleted_at = now() WHERE id = 1 AND deleted_at IS NULL",
+		`"public"."users" u`: `UPDATE "public"."users" u SET deleted_at = now() WHERE id = 1 AND deleted_at IS NULL`,
+		"ONLY users":         "UPDATE ONLY users SET deleted_at = now() WHERE id = 1 AND deleted_at IS NULL",
+		"archive.users":      "UPDATE archive.users SET archived_at = now() WHERE id = 1 AND archived_at IS NULL",
+		"users_2024":         "DELETE FROM users_2024 WHERE id = 1",
+	}
+	for table, want := range tests {
+		q, _, err := NewDelete().From(table).Where("id = 1").Build()
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", table, err)
+			continue
+		}
+		if q != want {
+			t.Errorf("%s: SQL mismatch\n got: %s\nwant: %s", table, q, want)
+		}
+	}
+}
+
+func TestDeleteSoft_Immutability(t *testing.T) {
+	base := NewDelete().From("users").Where("id = :id", Params{"id": 1})
+	_ = base.Soft("deleted_at")
+
+	q, _, _ := base.Build()
+	if q != "DELETE FROM users WHERE id = :id" {
+		t.Errorf("base builder was mutated: %s", q)
+	}
+}
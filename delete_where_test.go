@@ -171,3 +171,40 @@ func TestDeleteWhereImmutability(t *testing.T) {
 		t.Errorf("expected 1 param, got %d", len(params))
 	}
 }
+
+func TestDeleteRemoveWhere(t *testing.T) {
+	q, _, err := NewDelete().From("users").
+		WhereTagged("stale", "last_seen < now() - interval '1 year'").
+		Where("active = :active", Params{"active": false}).
+		RemoveWhere("stale").
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "DELETE FROM users WHERE active = :active"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+
+	_, _, err = NewDelete().From("users").WhereTagged("t", "id = :id").RemoveWhere("t").Build()
+	if !errors.Is(err, ErrMissingParam) {
+		t.Errorf("errors of removed clauses should be kept, got: %v", err)
+	}
+}
+
+func TestDeleteRemoveWhere_ResetsPrefix(t *testing.T) {
+	q, _, err := NewDelete().From("users").
+		WhereTagged("x", "a = @a", Params{"a": 1}).
+		RemoveWhere("x").
+		Where("b = :b", Params{"b": 2}).
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "DELETE FROM users WHERE b = :b"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+}
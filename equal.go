@@ -0,0 +1,318 @@
+package squildx
+
+import (
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+	"maps"
+	"math"
+	"reflect"
+	"slices"
+)
+
+// Equal reports whether a and b are the same query: the same clauses, params,
+// scopes and subqueries, compared without building either. Clause tags and
+// the naming strategy, which do not change the query, are ignored. Builders
+// with the same errors are equal. Other implementations of Builder are
+// compared by building them, and are never equal to one returned by New.
+func Equal(a, b Builder) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	ba, okA := a.(*builder)
+	bb, okB := b.(*builder)
+	if !okA || !okB {
+		return !okA && !okB && builtEqual(a, b)
+	}
+	if ba == bb {
+		return true
+	}
+	return ba.distinct == bb.distinct &&
+		ba.from == bb.from &&
+		ba.namedQuery == bb.namedQuery &&
+		ba.paramPrefix == bb.paramPrefix &&
+		optionalEqual(ba.limit, bb.limit) &&
+		optionalEqual(ba.offset, bb.offset) &&
+		slices.Equal(ba.columns, bb.columns) &&
+		slices.Equal(ba.groupBys, bb.groupBys) &&
+		paramsEqual(ba.fromParams, bb.fromParams) &&
+		slices.EqualFunc(ba.joins, bb.joins, joinsEqual) &&
+		slices.EqualFunc(ba.wheres, bb.wheres, clausesEqual) &&
+		slices.EqualFunc(ba.havings, bb.havings, clausesEqual) &&
+		slices.EqualFunc(ba.orderBys, bb.orderBys, clausesEqual) &&
+		scopingEqual(ba.scoping, bb.scoping) &&
+		maps.Equal(ba.renamed, bb.renamed) &&
+		slices.EqualFunc(ba.errs, bb.errs, errorsEqual)
+}
+
+// builtEqual compares the SQL and params a and b build to.
+func builtEqual(a, b Builder) bool {
+	sqlA, paramsA, errA := a.Build()
+	sqlB, paramsB, errB := b.Build()
+	if errA != nil || errB != nil {
+		return false
+	}
+	return sqlA == sqlB && paramsEqual(paramsA, paramsB)
+}
+
+func optionalEqual(a, b *uint64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func clausesEqual(a, b paramClause) bool {
+	return a.sql == b.sql &&
+		a.bind == b.bind &&
+		a.subPrefix == b.subPrefix &&
+		paramsEqual(a.params, b.params) &&
+		Equal(a.subQuery, b.subQuery)
+}
+
+func joinsEqual(a, b joinClause) bool {
+	return a.joinType == b.joinType &&
+		a.alias == b.alias &&
+		clausesEqual(a.clause, b.clause) &&
+		Equal(a.subQuery, b.subQuery)
+}
+
+func scopingEqual(a, b scoping) bool {
+	return a.unscopedAll == b.unscopedAll &&
+		slices.Equal(a.unscoped, b.unscoped) &&
+		slices.EqualFunc(a.scopes, b.scopes, scopesEqual)
+}
+
+func scopesEqual(a, b Scope) bool {
+	return a.name == b.name &&
+		a.sql == b.sql &&
+		a.prefix == b.prefix &&
+		slices.Equal(a.tables, b.tables) &&
+		paramsEqual(a.params, b.params) &&
+		errorsEqual(a.err, b.err)
+}
+
+func errorsEqual(a, b error) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Error() == b.Error()
+}
+
+// Hash returns a hash of q that is the same for queries that are Equal, e.g.
+// to key a cache of query results. Different queries may share a hash, so a
+// cache should confirm a hit with Equal.
+func Hash(q Builder) uint64 {
+	h := fnv.New64a()
+	hashBuilder(h, q)
+	return h.Sum64()
+}
+
+func hashBuilder(h hash.Hash64, q Builder) {
+	if q == nil {
+		hashBytes(h, 0)
+		return
+	}
+	b, ok := q.(*builder)
+	if !ok {
+		// Equal builds other implementations, and so must Hash.
+		hashBytes(h, 1)
+		sql, params, err := q.Build()
+		if err == nil {
+			hashString(h, sql)
+			hashParams(h, params)
+		}
+		return
+	}
+	hashBytes(h, 2, b.paramPrefix)
+	hashBool(h, b.distinct)
+	hashStrings(h, b.columns)
+	hashString(h, b.from)
+	hashString(h, b.namedQuery)
+	hashParams(h, b.fromParams)
+	hashInt(h, len(b.joins))
+	for _, j := range b.joins {
+		hashString(h, string(j.joinType))
+		hashString(h, j.alias)
+		hashClause(h, j.clause)
+		hashBuilder(h, j.subQuery)
+	}
+	hashClauses(h, b.wheres)
+	hashStrings(h, b.groupBys)
+	hashClauses(h, b.havings)
+	hashClauses(h, b.orderBys)
+	hashOptional(h, b.limit)
+	hashOptional(h, b.offset)
+
+	hashBool(h, b.scoping.unscopedAll)
+	hashStrings(h, b.scoping.unscoped)
+	hashInt(h, len(b.scoping.scopes))
+	for _, s := range b.scoping.scopes {
+		hashString(h, s.name)
+		hashString(h, s.sql)
+		hashBytes(h, s.prefix)
+		hashStrings(h, s.tables)
+		hashParams(h, s.params)
+		hashError(h, s.err)
+	}
+	var renamed uint64
+	for k, v := range b.renamed {
+		eh := fnv.New64a()
+		hashString(eh, k)
+		hashString(eh, v)
+		renamed += eh.Sum64()
+	}
+	hashInt(h, len(b.renamed))
+	hashUint(h, renamed)
+
+	hashInt(h, len(b.errs))
+	for _, err := range b.errs {
+		hashError(h, err)
+	}
+}
+
+func hashClauses(h hash.Hash64, cs []paramClause) {
+	hashInt(h, len(cs))
+	for _, c := range cs {
+		hashClause(h, c)
+	}
+}
+
+func hashClause(h hash.Hash64, c paramClause) {
+	hashString(h, c.sql)
+	hashString(h, c.bind)
+	hashString(h, c.subPrefix)
+	hashParams(h, c.params)
+	hashBuilder(h, c.subQuery)
+}
+
+// hashParams hashes p independently of its iteration order.
+func hashParams(h hash.Hash64, p Params) {
+	var sum uint64
+	for k, v := range p {
+		eh := fnv.New64a()
+		hashString(eh, k)
+		hashValue(eh, reflect.ValueOf(v), 0)
+		sum += eh.Sum64()
+	}
+	hashInt(h, len(p))
+	hashUint(h, sum)
+}
+
+// maxHashDepth bounds the recursion of hashValue, which would otherwise not
+// end for cyclic values.
+const maxHashDepth = 16
+
+// hashValue hashes v consistently with reflect.DeepEqual, which valueEqual
+// uses to compare param values.
+func hashValue(h hash.Hash64, v reflect.Value, depth int) {
+	if !v.IsValid() {
+		hashBytes(h, 0)
+		return
+	}
+	hashString(h, v.Type().String())
+	if depth > maxHashDepth {
+		return
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		hashBool(h, v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		hashUint(h, uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		hashUint(h, v.Uint())
+	case reflect.Float32, reflect.Float64:
+		hashFloat(h, v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		hashFloat(h, real(v.Complex()))
+		hashFloat(h, imag(v.Complex()))
+	case reflect.String:
+		hashString(h, v.String())
+	case reflect.Pointer, reflect.Interface:
+		hashBool(h, v.IsNil())
+		if !v.IsNil() {
+			hashValue(h, v.Elem(), depth+1)
+		}
+	case reflect.Slice, reflect.Array:
+		hashInt(h, v.Len())
+		for i := range v.Len() {
+			hashValue(h, v.Index(i), depth+1)
+		}
+	case reflect.Map:
+		var sum uint64
+		iter := v.MapRange()
+		for iter.Next() {
+			eh := fnv.New64a()
+			hashValue(eh, iter.Key(), depth+1)
+			hashValue(eh, iter.Value(), depth+1)
+			sum += eh.Sum64()
+		}
+		hashInt(h, v.Len())
+		hashUint(h, sum)
+	case reflect.Struct:
+		for i := range v.NumField() {
+			hashValue(h, v.Field(i), depth+1)
+		}
+	case reflect.Chan, reflect.UnsafePointer:
+		hashUint(h, uint64(v.Pointer()))
+	case reflect.Func:
+		// Funcs are only DeepEqual when both are nil, so the type is enough.
+	}
+}
+
+func hashError(h hash.Hash64, err error) {
+	if err == nil {
+		hashBytes(h, 0)
+		return
+	}
+	hashBytes(h, 1)
+	hashString(h, err.Error())
+}
+
+func hashOptional(h hash.Hash64, n *uint64) {
+	hashBool(h, n != nil)
+	if n != nil {
+		hashUint(h, *n)
+	}
+}
+
+func hashStrings(h hash.Hash64, ss []string) {
+	hashInt(h, len(ss))
+	for _, s := range ss {
+		hashString(h, s)
+	}
+}
+
+// hashString writes s with its length, so that adjacent strings cannot run
+// into each other.
+func hashString(h hash.Hash64, s string) {
+	hashInt(h, len(s))
+	h.Write([]byte(s))
+}
+
+func hashFloat(h hash.Hash64, f float64) {
+	if f == 0 {
+		f = 0 // -0 and 0 are DeepEqual
+	}
+	hashUint(h, math.Float64bits(f))
+}
+
+func hashInt(h hash.Hash64, n int) {
+	hashUint(h, uint64(n))
+}
+
+func hashUint(h hash.Hash64, n uint64) {
+	h.Write(binary.LittleEndian.AppendUint64(nil, n))
+}
+
+func hashBool(h hash.Hash64, b bool) {
+	if b {
+		hashBytes(h, 1)
+	} else {
+		hashBytes(h, 0)
+	}
+}
+
+func hashBytes(h hash.Hash64, b ...byte) {
+	h.Write(b)
+}
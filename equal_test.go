@@ -0,0 +1,146 @@
+package squildx
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Builder
+		want bool
+	}{
+		{
+			"identical builders",
+			New().Select("*").From("users").Where("id = :id", Params{"id": 1}),
+			New().Select("*").From("users").Where("id = :id", Params{"id": 1}),
+			true,
+		},
+		{
+			"different SQL",
+			New().Select("*").From("users"),
+			New().Select("*").From("orders"),
+			false,
+		},
+		{
+			"different params",
+			New().Select("*").From("users").Where("id = :id", Params{"id": 1}),
+			New().Select("*").From("users").Where("id = :id", Params{"id": 2}),
+			false,
+		},
+		{
+			"first builder errors",
+			New().Select("*"), // missing From
+			New().Select("*").From("users"),
+			false,
+		},
+		{
+			"second builder errors",
+			New().Select("*").From("users"),
+			New().Select("*"), // missing From
+			false,
+		},
+		{
+			"both builders error",
+			New().Select("*"), // missing From
+			New().Select("*"), // missing From
+			true,
+		},
+		{
+			"different errors",
+			New().Select("*").From("users").Where("id = :id"),
+			New().Select("*").From("users").Where("id = :uid"),
+			false,
+		},
+		{
+			"tags are ignored",
+			New().Select("*").From("users").WhereTagged("pk", "id = :id", Params{"id": 1}),
+			New().Select("*").From("users").Where("id = :id", Params{"id": 1}),
+			true,
+		},
+		{
+			"limit",
+			New().Select("*").From("users").Limit(1),
+			New().Select("*").From("users").Limit(2),
+			false,
+		},
+		{
+			"equal subqueries",
+			New().Select("*").From("users").WhereIn("id", New().Select("user_id").From("orders")),
+			New().Select("*").From("users").WhereIn("id", New().Select("user_id").From("orders")),
+			true,
+		},
+		{
+			"different subqueries",
+			New().Select("*").From("users").WhereIn("id", New().Select("user_id").From("orders")),
+			New().Select("*").From("users").WhereIn("id", New().Select("user_id").From("bans")),
+			false,
+		},
+		{
+			"scopes",
+			New().Select("*").From("users").Scoped(tenantScope(1)),
+			New().Select("*").From("users").Scoped(tenantScope(2)),
+			false,
+		},
+		{
+			"unscoped",
+			New().Select("*").From("users").Unscoped("tenant"),
+			New().Select("*").From("users"),
+			false,
+		},
+		{"both nil", nil, nil, true},
+		{"one nil", New().Select("*").From("users"), nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Equal(tt.a, tt.b); got != tt.want {
+				t.Errorf("Equal() = %v, want %v", got, tt.want)
+			}
+			if got := Equal(tt.b, tt.a); got != tt.want {
+				t.Errorf("Equal() reversed = %v, want %v", got, tt.want)
+			}
+			if tt.want && Hash(tt.a) != Hash(tt.b) {
+				t.Errorf("equal builders hash differently: %d != %d", Hash(tt.a), Hash(tt.b))
+			}
+			if !tt.want && tt.a != nil && tt.b != nil && Hash(tt.a) == Hash(tt.b) {
+				t.Errorf("different builders share hash %d", Hash(tt.a))
+			}
+		})
+	}
+}
+
+func TestHash_ParamValues(t *testing.T) {
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	name := "alice"
+	build := func(p Params) Builder {
+		return New().Select("*").From("users").Where("a = :a AND b = :b AND c = :c AND d = :d", p)
+	}
+
+	a := build(Params{"a": []int{1, 2}, "b": map[string]any{"x": 1, "y": "z"}, "c": &name, "d": at})
+	other := "alice"
+	b := build(Params{"d": at, "c": &other, "b": map[string]any{"y": "z", "x": 1}, "a": []int{1, 2}})
+	if !Equal(a, b) {
+		t.Fatal("expected builders to be equal")
+	}
+	if Hash(a) != Hash(b) {
+		t.Errorf("equal builders hash differently: %d != %d", Hash(a), Hash(b))
+	}
+
+	c := build(Params{"a": []int{1, 2}, "b": map[string]any{"x": 1, "y": "z"}, "c": &name, "d": at.Add(time.Second)})
+	if Equal(a, c) || Hash(a) == Hash(c) {
+		t.Error("expected a different time to change equality and hash")
+	}
+
+	d := build(Params{"a": []int64{1, 2}, "b": map[string]any{"x": 1, "y": "z"}, "c": &name, "d": at})
+	if Equal(a, d) || Hash(a) == Hash(d) {
+		t.Error("expected a different element type to change equality and hash")
+	}
+}
+
+func TestHash_Stable(t *testing.T) {
+	q := New().Select("id").From("users").Where("id = :id", Params{"id": 1})
+	if Hash(q) != Hash(q.Limit(1).ClearLimit()) {
+		t.Error("expected the hash to be the same for a rebuilt query")
+	}
+}
@@ -1,6 +1,9 @@
 package squildx
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	ErrNoColumns            = errors.New("squildx: SELECT requires at least one column")
@@ -12,17 +15,62 @@ var (
 	ErrMixedPrefix          = errors.New("squildx: mixed parameter prefixes (: and @) in the same query")
 	ErrHavingWithoutGroupBy = errors.New("squildx: HAVING requires a GROUP BY clause")
 	ErrNotAStruct           = errors.New("squildx: SelectObject requires a struct or pointer to struct")
+	ErrUnknownField         = errors.New("squildx: column is not a settable field of the struct")
+	ErrNoPrimaryKey         = errors.New("squildx: struct has no field tagged pk")
+	ErrNilVersion           = errors.New("squildx: struct field tagged version is nil")
+	ErrScanColumn           = errors.New("squildx: result column has no matching field in the struct")
 
 	ErrNoTable         = errors.New("squildx: INSERT requires a table (use Into)")
 	ErrNoInsertColumns = errors.New("squildx: INSERT requires at least one column")
 	ErrNoInsertValues  = errors.New("squildx: INSERT requires values, an object, or a SELECT subquery")
 	ErrValuesAndSelect = errors.New("squildx: INSERT cannot have both VALUES and a SELECT subquery")
 	ErrColumnMismatch  = errors.New("squildx: ValuesObject columns do not match previously set columns")
+	ErrNotCopyable     = errors.New("squildx: INSERT cannot be expressed as rows for COPY")
+
+	ErrConflictNoTarget    = errors.New("squildx: ON CONFLICT DO UPDATE requires conflict columns or a constraint")
+	ErrConflictWhereTarget = errors.New("squildx: ON CONFLICT WHERE requires conflict columns")
+	ErrConflictWhereAction = errors.New("squildx: ON CONFLICT DO UPDATE WHERE requires a DO UPDATE action")
+	ErrConflictNoUpdate    = errors.New("squildx: ON CONFLICT DO UPDATE has no columns left to update")
+
+	ErrDeleteNoTable  = errors.New("squildx: DELETE requires a table (use From)")
+	ErrDeleteNoWhere  = errors.New("squildx: DELETE requires at least one WHERE clause")
+	ErrDeleteAllWhere = errors.New("squildx: DELETE cannot combine All with a WHERE clause")
+
+	ErrUpdateNoTable      = errors.New("squildx: UPDATE requires a table (use Table)")
+	ErrUpdateNoSet        = errors.New("squildx: UPDATE requires at least one SET clause")
+	ErrUpdateNoWhere      = errors.New("squildx: UPDATE requires at least one WHERE clause")
+	ErrUpdateAllWhere     = errors.New("squildx: UPDATE cannot combine All with a WHERE clause")
+	ErrDuplicateSetColumn = errors.New("squildx: UPDATE assigns a column more than once")
 
-	ErrDeleteNoTable = errors.New("squildx: DELETE requires a table (use From)")
-	ErrDeleteNoWhere = errors.New("squildx: DELETE requires at least one WHERE clause")
+	ErrTruncateNoTable = errors.New("squildx: TRUNCATE requires at least one table (use Table)")
 
-	ErrUpdateNoTable = errors.New("squildx: UPDATE requires a table (use Table)")
-	ErrUpdateNoSet   = errors.New("squildx: UPDATE requires at least one SET clause")
-	ErrUpdateNoWhere = errors.New("squildx: UPDATE requires at least one WHERE clause")
+	ErrScopedNamedQuery = errors.New("squildx: scopes cannot apply to the tables of a named query (use Unscoped)")
+	ErrScopedTableRef   = errors.New("squildx: scopes cannot apply to a derived table or table function (use Unscoped)")
+
+	// ErrVersionConflict is returned by CheckVersion, not by Build.
+	ErrVersionConflict = errors.New("squildx: no row matched the expected version")
+	// ErrQueryFile is returned by LoadQueries.
+	ErrQueryFile = errors.New("squildx: invalid named query file")
+	// ErrParse is returned by Parse.
+	ErrParse = errors.New("squildx: cannot parse SQL")
 )
+
+// BuildError attributes a failure to the builder call that introduced it.
+// It wraps one of the sentinel errors above, so errors.Is keeps working.
+type BuildError struct {
+	Method string // builder method that added the failing clause, e.g. "Where"
+	Index  int    // zero-based position of the call among calls adding clauses of the same kind
+	SQL    string // SQL fragment passed to the method, if any
+	Err    error
+}
+
+func (e *BuildError) Error() string {
+	if e.SQL == "" {
+		return fmt.Sprintf("%s #%d: %v", e.Method, e.Index, e.Err)
+	}
+	return fmt.Sprintf("%s #%d %q: %v", e.Method, e.Index, e.SQL, e.Err)
+}
+
+func (e *BuildError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,176 @@
+package squildx
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBuildError_ClauseContext(t *testing.T) {
+	_, _, err := New().
+		Select("*").
+		From("users").
+		Where("active = :active", Params{"active": true}).
+		Where("id = :id").
+		Build()
+
+	var be *BuildError
+	if !errors.As(err, &be) {
+		t.Fatalf("expected *BuildError, got: %v", err)
+	}
+	if be.Method != "Where" || be.Index != 1 || be.SQL != "id = :id" {
+		t.Errorf("BuildError = {%s %d %q}, want {Where 1 %q}", be.Method, be.Index, be.SQL, "id = :id")
+	}
+	if !errors.Is(err, ErrMissingParam) {
+		t.Errorf("expected ErrMissingParam, got: %v", err)
+	}
+}
+
+func TestBuildError_Message(t *testing.T) {
+	err := &BuildError{Method: "Where", Index: 1, SQL: "id = :id", Err: ErrMissingParam}
+	want := `Where #1 "id = :id": ` + ErrMissingParam.Error()
+	if err.Error() != want {
+		t.Errorf("message mismatch\n got: %s\nwant: %s", err.Error(), want)
+	}
+
+	err = &BuildError{Method: "SelectObject", Index: 0, Err: ErrNotAStruct}
+	want = "SelectObject #0: " + ErrNotAStruct.Error()
+	if err.Error() != want {
+		t.Errorf("message mismatch\n got: %s\nwant: %s", err.Error(), want)
+	}
+}
+
+func TestBuildError_Accumulates(t *testing.T) {
+	_, _, err := New().
+		Select("*").
+		Where("id = :id").
+		OrderBy("name", Params{"extra": 1}).
+		Build()
+
+	for _, want := range []error{ErrMissingParam, ErrExtraParam, ErrNoFrom} {
+		if !errors.Is(err, want) {
+			t.Errorf("expected %v in joined error, got: %v", want, err)
+		}
+	}
+}
+
+func TestBuildError_MergeConflictAttributed(t *testing.T) {
+	_, _, err := New().
+		Select("*").
+		From("users").
+		Where("name = :name", Params{"name": "Alice"}).
+		OrderBy("name = :name DESC", Params{"name": "Bob"}).
+		Build()
+
+	var be *BuildError
+	if !errors.As(err, &be) {
+		t.Fatalf("expected *BuildError, got: %v", err)
+	}
+	if be.Method != "OrderBy" || be.Index != 0 {
+		t.Errorf("BuildError = {%s %d}, want {OrderBy 0}", be.Method, be.Index)
+	}
+	if !errors.Is(err, ErrDuplicateParam) {
+		t.Errorf("expected ErrDuplicateParam, got: %v", err)
+	}
+}
+
+func TestBuildError_Subquery(t *testing.T) {
+	sub := New().Select("user_id") // missing FROM
+
+	_, _, err := NewDelete().
+		From("users").
+		Where("active = :active", Params{"active": false}).
+		WhereIn("id", sub).
+		Build()
+
+	var be *BuildError
+	if !errors.As(err, &be) {
+		t.Fatalf("expected *BuildError, got: %v", err)
+	}
+	if be.Method != "WhereIn" || be.Index != 1 {
+		t.Errorf("BuildError = {%s %d}, want {WhereIn 1}", be.Method, be.Index)
+	}
+	if !errors.Is(err, ErrNoFrom) {
+		t.Errorf("expected ErrNoFrom, got: %v", err)
+	}
+}
+
+func TestBuildError_InsertAndUpdate(t *testing.T) {
+	_, _, err := NewInsert().
+		Into("users").
+		Columns("id").
+		Values(":id", Params{"id": 1}).
+		Values(":id", Params{"id": 2, "name": "x"}).
+		Build()
+
+	var be *BuildError
+	if !errors.As(err, &be) || be.Method != "Values" || be.Index != 1 {
+		t.Errorf("expected Values #1 BuildError, got: %v", err)
+	}
+
+	_, _, err = NewUpdate().
+		Table("users").
+		Set("name = :name").
+		Build()
+
+	if !errors.As(err, &be) || be.Method != "Set" || be.Index != 0 {
+		t.Errorf("expected Set #0 BuildError, got: %v", err)
+	}
+	if !errors.Is(err, ErrUpdateNoWhere) {
+		t.Errorf("expected ErrUpdateNoWhere alongside the Set error, got: %v", err)
+	}
+}
+
+func TestBuildError_IndexCountsFailedCalls(t *testing.T) {
+	_, _, err := New().
+		Select("*").
+		From("users").
+		Where("a = :a").
+		Where("b = :b").
+		Where("c = 1").
+		Where("d = :d").
+		Build()
+
+	var indexes []int
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var be *BuildError
+		if errors.As(e, &be) && be.Method == "Where" {
+			indexes = append(indexes, be.Index)
+		}
+	}
+	if len(indexes) != 3 || indexes[0] != 0 || indexes[1] != 1 || indexes[2] != 3 {
+		t.Errorf("Where indexes = %v, want [0 1 3]", indexes)
+	}
+}
+
+func TestBuildError_BuildTimeIndexCountsFailedCalls(t *testing.T) {
+	_, _, err := New().
+		Select("*").
+		From("users").
+		Where("a = :a").
+		Where("b = :x", Params{"x": 1}).
+		Where("c = :x", Params{"x": 2}).
+		Build()
+
+	var indexes []int
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var be *BuildError
+		if errors.As(e, &be) && errors.Is(be, ErrDuplicateParam) {
+			indexes = append(indexes, be.Index)
+		}
+	}
+	if len(indexes) != 1 || indexes[0] != 2 {
+		t.Errorf("duplicate param indexes = %v, want [2]", indexes)
+	}
+
+	_, _, err = New().
+		Select("*").
+		From("users").
+		Where("b = :x", Params{"x": 1}).
+		WhereTagged("tenant", "c = :x", Params{"x": 2}).
+		Build()
+
+	var be *BuildError
+	if !errors.As(err, &be) || be.Method != "WhereTagged" || be.Index != 1 {
+		t.Errorf("expected WhereTagged #1 BuildError, got: %v", err)
+	}
+}
@@ -3,5 +3,36 @@ package squildx
 func (b *builder) From(table string) Builder {
 	cp := b.clone()
 	cp.from = table
+	cp.fromParams = nil
+	cp.namedQuery = ""
+	cp.resetPrefix()
+	return cp
+}
+
+// ReplaceFrom replaces the FROM clause, like From, but with a table expression
+// that may contain placeholders, such as a derived table or a function call:
+//
+//	b.ReplaceFrom("generate_series(1, :n) AS s(i)", squildx.Params{"n": 10})
+func (b *builder) ReplaceFrom(table string, params ...Params) Builder {
+	cp := b.clone()
+	cp.from = table
+	cp.fromParams = nil
+	cp.namedQuery = ""
+	cp.resetPrefix()
+	p, err := extractParams(params)
+	if err != nil {
+		cp.fail("ReplaceFrom", 0, table, err)
+		return cp
+	}
+	parsed, prefix, err := parseParams(table, p)
+	if err != nil {
+		cp.fail("ReplaceFrom", 0, table, err)
+		return cp
+	}
+	if err := cp.setPrefix(prefix); err != nil {
+		cp.fail("ReplaceFrom", 0, table, err)
+		return cp
+	}
+	cp.fromParams = parsed
 	return cp
 }
@@ -23,3 +23,57 @@ func TestNamedTableFrom(t *testing.T) {
 		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
 	}
 }
+
+func TestReplaceFrom(t *testing.T) {
+	q, params, err := New().Select("s.i").
+		From("users").
+		ReplaceFrom("generate_series(1, :n) AS s(i)", Params{"n": 3}).
+		Where("s.i > :min", Params{"min": 1}).
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "SELECT s.i FROM generate_series(1, :n) AS s(i) WHERE s.i > :min"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+	assertParam(t, params, "n", 3)
+	assertParam(t, params, "min", 1)
+}
+
+func TestReplaceFrom_Errors(t *testing.T) {
+	_, _, err := New().Select("*").ReplaceFrom("generate_series(1, :n)").Build()
+	if !errors.Is(err, ErrMissingParam) {
+		t.Errorf("expected ErrMissingParam, got: %v", err)
+	}
+
+	_, _, err = New().Select("*").Where("id = @id", Params{"id": 1}).ReplaceFrom("f(:n)", Params{"n": 1}).Build()
+	if !errors.Is(err, ErrMixedPrefix) {
+		t.Errorf("expected ErrMixedPrefix, got: %v", err)
+	}
+}
+
+func TestReplaceFrom_ResetsPrefix(t *testing.T) {
+	q, _, err := New().Select("*").
+		ReplaceFrom("f(@n)", Params{"n": 1}).
+		From("t").
+		Where("b = :b", Params{"b": 2}).
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "SELECT * FROM t WHERE b = :b"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+
+	_, _, err = New().Select("*").
+		ReplaceFrom("f(@n)", Params{"n": 1}).
+		ReplaceFrom("g(:m)", Params{"m": 2}).
+		Build()
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -4,18 +4,23 @@ func (b *builder) Having(sql string, params ...Params) Builder {
 	cp := b.clone()
 	p, err := extractParams(params)
 	if err != nil {
-		cp.err = err
+		cp.failAdd("havings", "Having", len(cp.havings), sql, err)
 		return cp
 	}
 	parsed, prefix, err := parseParams(sql, p)
 	if err != nil {
-		cp.err = err
+		cp.failAdd("havings", "Having", len(cp.havings), sql, err)
 		return cp
 	}
 	if err := cp.setPrefix(prefix); err != nil {
-		cp.err = err
+		cp.failAdd("havings", "Having", len(cp.havings), sql, err)
 		return cp
 	}
-	cp.havings = append(cp.havings, paramClause{sql: sql, params: parsed})
+	cp.havings = append(cp.havings, paramClause{
+		sql:    sql,
+		params: parsed,
+		method: "Having",
+		index:  cp.callIndex("havings", len(cp.havings)),
+	})
 	return cp
 }
@@ -3,34 +3,26 @@ package squildx
 import "strings"
 
 func (b *insertBuilder) Build() (string, Params, error) {
-	if b.err != nil {
-		return "", nil, b.err
-	}
-
-	if b.table == "" {
-		return "", nil, ErrNoTable
-	}
-	if len(b.columns) == 0 {
-		return "", nil, ErrNoInsertColumns
-	}
+	r := newRenderer(b.paramPrefix, b.errs)
+	r.scopes = scoping{}.active(nil) // passed on to the SELECT subquery
+	hasValues, hasSelect := b.check(r)
 
-	hasValues := len(b.valueRows) > 0
-	hasSelect := b.selectQuery != nil
-	switch {
-	case hasValues && hasSelect:
-		return "", nil, ErrValuesAndSelect
-	case !hasValues && !hasSelect:
-		return "", nil, ErrNoInsertValues
+	var audit []auditValue
+	if hasValues && !hasSelect {
+		p := resolveAudit(b.audit)
+		audit = p.values(r, []string{p.CreatedAt, p.UpdatedAt}, []string{p.CreatedBy}, b.columns)
 	}
 
-	params := make(Params)
-
 	var sb strings.Builder
 
 	sb.WriteString("INSERT INTO ")
 	sb.WriteString(b.table)
 	sb.WriteString(" (")
 	sb.WriteString(strings.Join(b.columns, ", "))
+	for _, a := range audit {
+		sb.WriteString(", ")
+		sb.WriteString(a.column)
+	}
 	sb.WriteString(")")
 
 	switch {
@@ -42,41 +34,21 @@ func (b *insertBuilder) Build() (string, Params, error) {
 			}
 			sb.WriteString("(")
 			sb.WriteString(row.sql)
-			sb.WriteString(")")
-			if err := mergeParams(params, row.params); err != nil {
-				return "", nil, err
+			for _, a := range audit {
+				sb.WriteString(", ")
+				sb.WriteString(a.expr)
 			}
+			sb.WriteString(")")
+			r.merge(row.method, row.index, row.sql, row.params)
 		}
 	case hasSelect:
-		subSQL, subParams, err := b.selectQuery.Build()
-		if err != nil {
-			return "", nil, err
-		}
-		subPrefix := detectPrefix(subSQL)
-		if _, err := reconcilePrefix(b.paramPrefix, subPrefix); err != nil {
-			return "", nil, err
-		}
+		subSQL, _ := r.subquery("Select", 0, b.selectQuery)
 		sb.WriteString(" ")
 		sb.WriteString(subSQL)
-		if err := mergeParams(params, subParams); err != nil {
-			return "", nil, err
-		}
 	}
 
 	if b.conflict != nil {
-		sb.WriteString(" ON CONFLICT (")
-		sb.WriteString(strings.Join(b.conflict.columns, ", "))
-		sb.WriteString(")")
-		switch {
-		case b.conflict.doUpdate:
-			sb.WriteString(" DO UPDATE SET ")
-			sb.WriteString(b.conflict.set)
-			if err := mergeParams(params, b.conflict.params); err != nil {
-				return "", nil, err
-			}
-		default:
-			sb.WriteString(" DO NOTHING")
-		}
+		sb.WriteString(b.conflict.render(r))
 	}
 
 	if len(b.returnings) > 0 {
@@ -84,5 +56,72 @@ func (b *insertBuilder) Build() (string, Params, error) {
 		sb.WriteString(strings.Join(b.returnings, ", "))
 	}
 
-	return sb.String(), params, nil
+	return r.result(sb.String())
+}
+
+// check records the errors in the structure of the statement, such as a
+// missing table, without rendering it.
+func (b *insertBuilder) check(r *renderer) (hasValues, hasSelect bool) {
+	if b.table == "" {
+		r.fail(ErrNoTable)
+	}
+	if len(b.columns) == 0 {
+		r.fail(ErrNoInsertColumns)
+	}
+
+	hasValues = len(b.valueRows) > 0
+	hasSelect = b.selectQuery != nil
+	switch {
+	case hasValues && hasSelect:
+		r.fail(ErrValuesAndSelect)
+	case !hasValues && !hasSelect:
+		r.fail(ErrNoInsertValues)
+	}
+	return hasValues, hasSelect
+}
+
+func (c *conflictClause) render(r *renderer) string {
+	var sb strings.Builder
+
+	sb.WriteString(" ON CONFLICT")
+	switch {
+	case c.constraint != "":
+		sb.WriteString(" ON CONSTRAINT ")
+		sb.WriteString(c.constraint)
+	case len(c.columns) > 0:
+		sb.WriteString(" (")
+		sb.WriteString(strings.Join(c.columns, ", "))
+		sb.WriteString(")")
+	}
+
+	if c.where.sql != "" {
+		if len(c.columns) == 0 {
+			r.fail(ErrConflictWhereTarget)
+		}
+		sb.WriteString(" WHERE ")
+		sb.WriteString(c.where.sql)
+		r.merge("OnConflictWhere", 0, c.where.sql, c.where.params)
+	}
+
+	if !c.doUpdate {
+		if c.updateWhere.sql != "" {
+			r.fail(ErrConflictWhereAction)
+		}
+		sb.WriteString(" DO NOTHING")
+		return sb.String()
+	}
+
+	if c.constraint == "" && len(c.columns) == 0 {
+		r.fail(ErrConflictNoTarget)
+	}
+	sb.WriteString(" DO UPDATE SET ")
+	sb.WriteString(c.set)
+	r.merge("OnConflictDoUpdate", 0, c.set, c.params)
+
+	if c.updateWhere.sql != "" {
+		sb.WriteString(" WHERE ")
+		sb.WriteString(c.updateWhere.sql)
+		r.merge("OnConflictDoUpdateWhere", 0, c.updateWhere.sql, c.updateWhere.params)
+	}
+	return sb.String()
 }
@@ -1,5 +1,7 @@
 package squildx
 
+import "maps"
+
 // InsertBuilder provides a fluent, immutable API for constructing INSERT queries.
 type InsertBuilder interface {
 	Into(table string) InsertBuilder
@@ -10,9 +12,21 @@ type InsertBuilder interface {
 	Select(sub Builder) InsertBuilder
 	OnConflictDoNothing(columns ...string) InsertBuilder
 	OnConflictDoUpdate(columns []string, set string, params ...Params) InsertBuilder
+	OnConflictDoUpdateExcluded(conflictColumns []string, updateColumns ...string) InsertBuilder
+	OnConflictDoUpdateObject(conflictColumns []string, obj any) InsertBuilder
+	OnConflictOnConstraint(name string) InsertBuilder
+	OnConflictWhere(sql string, params ...Params) InsertBuilder
+	OnConflictDoUpdateWhere(sql string, params ...Params) InsertBuilder
 	Returning(columns ...string) InsertBuilder
 	ReturningObject(obj any) InsertBuilder
+	Naming(ns NamingStrategy) InsertBuilder
+	Audit(p AuditPolicy) InsertBuilder
+	TableName() string
+	ColumnNames() []string
 	Build() (string, Params, error)
+	BuildArgs() (string, []any, error)
+	Compile() (*Template, error)
+	CopyRows() (table string, columns []string, rows [][]any, err error)
 }
 
 type insertBuilder struct {
@@ -22,15 +36,21 @@ type insertBuilder struct {
 	selectQuery Builder
 	conflict    *conflictClause
 	returnings  []string
+	naming      NamingStrategy // nil = global strategy
+	audit       *AuditPolicy   // nil = global policy
 	paramPrefix byte
-	err         error
+	failed      map[string]int // failed calls per clause kind, see failAdd
+	errs        []error
 }
 
 type conflictClause struct {
-	columns  []string
-	doUpdate bool
-	set      string
-	params   Params
+	columns     []string
+	constraint  string
+	where       paramClause // index predicate of the conflict target
+	doUpdate    bool
+	set         string
+	params      Params
+	updateWhere paramClause
 }
 
 func NewInsert() InsertBuilder {
@@ -47,9 +67,31 @@ func (b *insertBuilder) clone() *insertBuilder {
 		cc.columns = copySlice(b.conflict.columns)
 		cp.conflict = &cc
 	}
+	cp.failed = maps.Clone(b.failed)
+	cp.errs = copySlice(b.errs)
 	return &cp
 }
 
 func (b *insertBuilder) setPrefix(prefix byte) error {
 	return checkSetPrefix(&b.paramPrefix, prefix)
 }
+
+func (b *insertBuilder) fail(method string, index int, sql string, err error) {
+	b.errs = append(b.errs, &BuildError{Method: method, Index: index, SQL: sql, Err: err})
+}
+
+// callIndex returns the position of a call adding a clause of kind, as
+// builder.callIndex does.
+func (b *insertBuilder) callIndex(kind string, n int) int {
+	return n + b.failed[kind]
+}
+
+// failAdd records err for a call that failed to add a clause of kind, as
+// builder.failAdd does.
+func (b *insertBuilder) failAdd(kind, method string, n int, sql string, err error) {
+	b.fail(method, b.callIndex(kind, n), sql, err)
+	if b.failed == nil {
+		b.failed = make(map[string]int)
+	}
+	b.failed[kind]++
+}
@@ -8,9 +8,9 @@ func (b *insertBuilder) Columns(columns ...string) InsertBuilder {
 
 func (b *insertBuilder) ColumnsObject(obj any) InsertBuilder {
 	cp := b.clone()
-	cols, err := structColumns(obj, "")
+	cols, err := structColumns(obj, "", optReadonly|optOmitInsert|optNested, cp.naming)
 	if err != nil {
-		cp.err = err
+		cp.failAdd("columns", "ColumnsObject", len(cp.columns), "", err)
 		return cp
 	}
 	cp.columns = append(cp.columns, cols...)
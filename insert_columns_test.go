@@ -55,3 +55,19 @@ func TestInsertColumnsObject_NotAStruct(t *testing.T) {
 		t.Errorf("expected ErrNotAStruct, got: %v", err)
 	}
 }
+
+func TestInsertColumnsObject_TagOptions(t *testing.T) {
+	type User struct {
+		ID     int    `squildx:"id,pk,readonly"`
+		Name   string `db:"name"`
+		Search string `squildx:"search,omitinsert"`
+		Role   string `squildx:"role,default,omitempty"`
+	}
+
+	b := NewInsert().ColumnsObject(User{})
+	ib := b.(*insertBuilder)
+	want := []string{"name", "role"}
+	if !reflect.DeepEqual(ib.columns, want) {
+		t.Errorf("columns = %v, want %v", ib.columns, want)
+	}
+}
@@ -1,34 +1,184 @@
 package squildx
 
+import (
+	"slices"
+	"strings"
+)
+
+// OnConflictDoNothing sets the conflict action to DO NOTHING. Columns, when
+// given, replace the conflict target; with no columns the current target is
+// kept, and an insert without any target renders ON CONFLICT DO NOTHING.
 func (b *insertBuilder) OnConflictDoNothing(columns ...string) InsertBuilder {
 	cp := b.clone()
-	cp.conflict = &conflictClause{
-		columns: copySlice(columns),
-	}
+	cp.ensureConflict()
+	cp.conflict.setTarget(columns)
+	cp.conflict.doUpdate = false
+	cp.conflict.set = ""
+	cp.conflict.params = nil
+	cp.conflict.updateWhere = paramClause{}
 	return cp
 }
 
+// OnConflictDoUpdate sets the conflict action to DO UPDATE SET set. Columns,
+// when given, replace the conflict target; with no columns the current target
+// (e.g. from OnConflictOnConstraint) is kept.
 func (b *insertBuilder) OnConflictDoUpdate(columns []string, set string, params ...Params) InsertBuilder {
 	cp := b.clone()
 	extracted, err := extractParams(params)
 	if err != nil {
-		cp.err = err
+		cp.fail("OnConflictDoUpdate", 0, set, err)
 		return cp
 	}
 	merged, prefix, err := parseParams(set, extracted)
 	if err != nil {
-		cp.err = err
+		cp.fail("OnConflictDoUpdate", 0, set, err)
 		return cp
 	}
 	if err := cp.setPrefix(prefix); err != nil {
-		cp.err = err
+		cp.fail("OnConflictDoUpdate", 0, set, err)
+		return cp
+	}
+	cp.ensureConflict()
+	cp.conflict.setTarget(columns)
+	cp.conflict.doUpdate = true
+	cp.conflict.set = set
+	cp.conflict.params = merged
+	return cp
+}
+
+// OnConflictDoUpdateExcluded sets the conflict action to
+// DO UPDATE SET col = EXCLUDED.col for each update column. Without update
+// columns, the insert columns set so far are used. Conflict columns are never
+// assigned, since they are equal to the excluded row by definition.
+func (b *insertBuilder) OnConflictDoUpdateExcluded(conflictColumns []string, updateColumns ...string) InsertBuilder {
+	cp := b.clone()
+	if len(updateColumns) == 0 {
+		updateColumns = cp.columns
+	}
+	set := excludedAssignments(updateColumns, conflictColumns)
+	if set == "" {
+		cp.fail("OnConflictDoUpdateExcluded", 0, "", ErrConflictNoUpdate)
+		return cp
+	}
+	cp.ensureConflict()
+	cp.conflict.setTarget(conflictColumns)
+	cp.conflict.doUpdate = true
+	cp.conflict.set = set
+	cp.conflict.params = nil
+	return cp
+}
+
+// OnConflictDoUpdateObject is like OnConflictDoUpdateExcluded, deriving the
+// update columns from the fields of obj. Fields tagged immutable
+// (`squildx:"created_at,immutable"`), pk, readonly or omitinsert are not updated.
+func (b *insertBuilder) OnConflictDoUpdateObject(conflictColumns []string, obj any) InsertBuilder {
+	cp := b.clone()
+	cols, err := structColumns(obj, "", optPK|optReadonly|optOmitInsert|optImmutable|optNested, cp.naming)
+	if err != nil {
+		cp.fail("OnConflictDoUpdateObject", 0, "", err)
 		return cp
 	}
-	cp.conflict = &conflictClause{
-		columns:  columns,
-		doUpdate: true,
-		set:      set,
-		params:   merged,
+	set := excludedAssignments(cols, conflictColumns)
+	if set == "" {
+		cp.fail("OnConflictDoUpdateObject", 0, "", ErrConflictNoUpdate)
+		return cp
 	}
+	cp.ensureConflict()
+	cp.conflict.setTarget(conflictColumns)
+	cp.conflict.doUpdate = true
+	cp.conflict.set = set
+	cp.conflict.params = nil
 	return cp
 }
+
+// excludedAssignments renders "col = EXCLUDED.col" for every column not in skip.
+func excludedAssignments(columns, skip []string) string {
+	var sb strings.Builder
+	for _, col := range columns {
+		if slices.Contains(skip, col) {
+			continue
+		}
+		if sb.Len() > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(col)
+		sb.WriteString(" = EXCLUDED.")
+		sb.WriteString(col)
+	}
+	return sb.String()
+}
+
+// OnConflictOnConstraint targets the named constraint instead of a column list.
+func (b *insertBuilder) OnConflictOnConstraint(name string) InsertBuilder {
+	cp := b.clone()
+	cp.ensureConflict()
+	cp.conflict.columns = nil
+	cp.conflict.where = paramClause{}
+	cp.conflict.constraint = name
+	return cp
+}
+
+// OnConflictWhere adds an index predicate to the conflict target, for
+// inferring partial unique indexes: ON CONFLICT (email) WHERE deleted_at IS NULL.
+func (b *insertBuilder) OnConflictWhere(sql string, params ...Params) InsertBuilder {
+	cp := b.clone()
+	extracted, err := extractParams(params)
+	if err != nil {
+		cp.fail("OnConflictWhere", 0, sql, err)
+		return cp
+	}
+	merged, prefix, err := parseParams(sql, extracted)
+	if err != nil {
+		cp.fail("OnConflictWhere", 0, sql, err)
+		return cp
+	}
+	if err := cp.setPrefix(prefix); err != nil {
+		cp.fail("OnConflictWhere", 0, sql, err)
+		return cp
+	}
+	cp.ensureConflict()
+	cp.conflict.where = paramClause{sql: sql, params: merged}
+	return cp
+}
+
+// OnConflictDoUpdateWhere makes the DO UPDATE conditional:
+// DO UPDATE SET ... WHERE t.version < EXCLUDED.version.
+func (b *insertBuilder) OnConflictDoUpdateWhere(sql string, params ...Params) InsertBuilder {
+	cp := b.clone()
+	extracted, err := extractParams(params)
+	if err != nil {
+		cp.fail("OnConflictDoUpdateWhere", 0, sql, err)
+		return cp
+	}
+	merged, prefix, err := parseParams(sql, extracted)
+	if err != nil {
+		cp.fail("OnConflictDoUpdateWhere", 0, sql, err)
+		return cp
+	}
+	if err := cp.setPrefix(prefix); err != nil {
+		cp.fail("OnConflictDoUpdateWhere", 0, sql, err)
+		return cp
+	}
+	cp.ensureConflict()
+	cp.conflict.updateWhere = paramClause{sql: sql, params: merged}
+	return cp
+}
+
+func (b *insertBuilder) ensureConflict() {
+	if b.conflict == nil {
+		b.conflict = &conflictClause{}
+	}
+}
+
+// setTarget replaces the conflict columns. The index predicate of a different
+// target is dropped, as it would otherwise apply to the new one.
+func (c *conflictClause) setTarget(columns []string) {
+	if len(columns) == 0 {
+		return
+	}
+	if !slices.Equal(c.columns, columns) {
+		c.where = paramClause{}
+	}
+	c.columns = copySlice(columns)
+	c.constraint = ""
+}
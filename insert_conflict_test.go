@@ -96,3 +96,283 @@ func TestInsertOnConflict_Immutability(t *testing.T) {
 		t.Error("base should not have conflict set")
 	}
 }
+
+func TestInsertOnConflictDoNothing_NoTarget(t *testing.T) {
+	q := NewInsert().Into("users").Columns("id", "name").
+		Values(":id, :name", Params{"id": 1, "name": "Alice"}).
+		OnConflictDoNothing()
+	sql, _, err := q.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "INSERT INTO users (id, name) VALUES (:id, :name) ON CONFLICT DO NOTHING"
+	if sql != want {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", sql, want)
+	}
+}
+
+func TestInsertOnConflictOnConstraint_DoNothing(t *testing.T) {
+	q := NewInsert().Into("users").Columns("id", "email").
+		Values(":id, :email", Params{"id": 1, "email": "a@b.com"}).
+		OnConflictOnConstraint("users_email_key")
+	sql, _, err := q.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "INSERT INTO users (id, email) VALUES (:id, :email) ON CONFLICT ON CONSTRAINT users_email_key DO NOTHING"
+	if sql != want {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", sql, want)
+	}
+}
+
+func TestInsertOnConflictOnConstraint_DoUpdate(t *testing.T) {
+	q := NewInsert().Into("users").Columns("id", "email").
+		Values(":id, :email", Params{"id": 1, "email": "a@b.com"}).
+		OnConflictOnConstraint("users_email_key").
+		OnConflictDoUpdate(nil, "email = EXCLUDED.email")
+	sql, _, err := q.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "INSERT INTO users (id, email) VALUES (:id, :email) ON CONFLICT ON CONSTRAINT users_email_key DO UPDATE SET email = EXCLUDED.email"
+	if sql != want {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", sql, want)
+	}
+}
+
+func TestInsertOnConflictOnConstraint_ReplacedByColumns(t *testing.T) {
+	q := NewInsert().Into("users").Columns("id").
+		Values(":id", Params{"id": 1}).
+		OnConflictOnConstraint("users_pkey").
+		OnConflictDoNothing("id")
+	sql, _, err := q.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "INSERT INTO users (id) VALUES (:id) ON CONFLICT (id) DO NOTHING"
+	if sql != want {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", sql, want)
+	}
+}
+
+func TestInsertOnConflictWhere(t *testing.T) {
+	q := NewInsert().Into("users").Columns("email", "name").
+		Values(":email, :name", Params{"email": "a@b.com", "name": "Alice"}).
+		OnConflictDoUpdate([]string{"email"}, "name = EXCLUDED.name").
+		OnConflictWhere("deleted_at IS NULL AND tenant_id = :tenant_id", Params{"tenant_id": 7})
+	sql, params, err := q.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "INSERT INTO users (email, name) VALUES (:email, :name) ON CONFLICT (email) WHERE deleted_at IS NULL AND tenant_id = :tenant_id DO UPDATE SET name = EXCLUDED.name"
+	if sql != want {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", sql, want)
+	}
+	assertParam(t, params, "tenant_id", 7)
+}
+
+func TestInsertOnConflictWhere_DroppedWithTarget(t *testing.T) {
+	base := NewInsert().Into("users").Columns("id", "email").
+		Values(":id, :email", Params{"id": 1, "email": "a@b.com"}).
+		OnConflictDoNothing("email").
+		OnConflictWhere("deleted_at IS NULL AND tenant_id = :tenant_id", Params{"tenant_id": 7})
+
+	sql, params, err := base.OnConflictDoNothing("id").Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "INSERT INTO users (id, email) VALUES (:id, :email) ON CONFLICT (id) DO NOTHING"
+	if sql != want {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", sql, want)
+	}
+	if _, ok := params["tenant_id"]; ok {
+		t.Error("params of the dropped predicate should be dropped")
+	}
+
+	sql, _, err = base.OnConflictDoUpdate([]string{"email"}, "email = EXCLUDED.email").Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want = "INSERT INTO users (id, email) VALUES (:id, :email) ON CONFLICT (email) WHERE deleted_at IS NULL AND tenant_id = :tenant_id DO UPDATE SET email = EXCLUDED.email"
+	if sql != want {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", sql, want)
+	}
+}
+
+func TestInsertOnConflictWhere_RequiresColumns(t *testing.T) {
+	q := NewInsert().Into("users").Columns("email").
+		Values(":email", Params{"email": "a@b.com"}).
+		OnConflictWhere("deleted_at IS NULL").
+		OnConflictOnConstraint("users_email_key")
+	_, _, err := q.Build()
+	if err != nil {
+		t.Fatalf("constraint target should drop the index predicate, got: %v", err)
+	}
+
+	q = NewInsert().Into("users").Columns("email").
+		Values(":email", Params{"email": "a@b.com"}).
+		OnConflictWhere("deleted_at IS NULL")
+	_, _, err = q.Build()
+	if !errors.Is(err, ErrConflictWhereTarget) {
+		t.Errorf("expected ErrConflictWhereTarget, got: %v", err)
+	}
+}
+
+func TestInsertOnConflictWhere_MissingParam(t *testing.T) {
+	q := NewInsert().Into("users").Columns("email").
+		Values(":email", Params{"email": "a@b.com"}).
+		OnConflictDoNothing("email").
+		OnConflictWhere("tenant_id = :tenant_id")
+	_, _, err := q.Build()
+	if !errors.Is(err, ErrMissingParam) {
+		t.Errorf("expected ErrMissingParam, got: %v", err)
+	}
+}
+
+func TestInsertOnConflictDoUpdateWhere(t *testing.T) {
+	q := NewInsert().Into("docs AS t").Columns("id", "body", "version").
+		Values(":id, :body, :version", Params{"id": 1, "body": "x", "version": 3}).
+		OnConflictDoUpdate([]string{"id"}, "body = EXCLUDED.body, version = EXCLUDED.version").
+		OnConflictDoUpdateWhere("t.version < EXCLUDED.version AND t.locked = :locked", Params{"locked": false})
+	sql, params, err := q.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "INSERT INTO docs AS t (id, body, version) VALUES (:id, :body, :version) ON CONFLICT (id) DO UPDATE SET body = EXCLUDED.body, version = EXCLUDED.version WHERE t.version < EXCLUDED.version AND t.locked = :locked"
+	if sql != want {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", sql, want)
+	}
+	assertParam(t, params, "locked", false)
+}
+
+func TestInsertOnConflictDoUpdateWhere_RequiresDoUpdate(t *testing.T) {
+	q := NewInsert().Into("docs").Columns("id").
+		Values(":id", Params{"id": 1}).
+		OnConflictDoUpdateWhere("docs.locked = FALSE").
+		OnConflictDoNothing("id")
+	_, _, err := q.Build()
+	if err != nil {
+		t.Fatalf("DoNothing should clear the update condition, got: %v", err)
+	}
+
+	q = NewInsert().Into("docs").Columns("id").
+		Values(":id", Params{"id": 1}).
+		OnConflictDoNothing("id").
+		OnConflictDoUpdateWhere("docs.locked = FALSE")
+	_, _, err = q.Build()
+	if !errors.Is(err, ErrConflictWhereAction) {
+		t.Errorf("expected ErrConflictWhereAction, got: %v", err)
+	}
+}
+
+func TestInsertOnConflictDoUpdate_NoTarget(t *testing.T) {
+	q := NewInsert().Into("users").Columns("id", "name").
+		Values(":id, :name", Params{"id": 1, "name": "Alice"}).
+		OnConflictDoUpdate(nil, "name = EXCLUDED.name")
+	_, _, err := q.Build()
+	if !errors.Is(err, ErrConflictNoTarget) {
+		t.Errorf("expected ErrConflictNoTarget, got: %v", err)
+	}
+}
+
+func TestInsertOnConflictDoUpdateWhere_Immutability(t *testing.T) {
+	base := NewInsert().Into("users").Columns("id", "name").
+		Values(":id, :name", Params{"id": 1, "name": "Alice"}).
+		OnConflictDoUpdate([]string{"id"}, "name = EXCLUDED.name")
+	_ = base.OnConflictDoUpdateWhere("users.locked = FALSE").OnConflictWhere("deleted_at IS NULL")
+	ib := base.(*insertBuilder)
+	if ib.conflict.updateWhere.sql != "" || ib.conflict.where.sql != "" {
+		t.Error("base conflict clause mutated")
+	}
+}
+
+func TestInsertOnConflictDoUpdateExcluded(t *testing.T) {
+	q := NewInsert().Into("users").Columns("id", "name", "email").
+		Values(":id, :name, :email", Params{"id": 1, "name": "Alice", "email": "a@b.com"}).
+		OnConflictDoUpdateExcluded([]string{"id"}, "name", "email")
+	sql, _, err := q.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "INSERT INTO users (id, name, email) VALUES (:id, :name, :email) ON CONFLICT (id) DO UPDATE SET name = EXCLUDED.name, email = EXCLUDED.email"
+	if sql != want {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", sql, want)
+	}
+}
+
+func TestInsertOnConflictDoUpdateExcluded_DefaultsToInsertColumns(t *testing.T) {
+	q := NewInsert().Into("users").Columns("id", "name", "email").
+		Values(":id, :name, :email", Params{"id": 1, "name": "Alice", "email": "a@b.com"}).
+		OnConflictDoUpdateExcluded([]string{"id"})
+	sql, _, err := q.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "INSERT INTO users (id, name, email) VALUES (:id, :name, :email) ON CONFLICT (id) DO UPDATE SET name = EXCLUDED.name, email = EXCLUDED.email"
+	if sql != want {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", sql, want)
+	}
+}
+
+func TestInsertOnConflictDoUpdateExcluded_NothingToUpdate(t *testing.T) {
+	q := NewInsert().Into("users").Columns("id").
+		Values(":id", Params{"id": 1}).
+		OnConflictDoUpdateExcluded([]string{"id"})
+	_, _, err := q.Build()
+	if !errors.Is(err, ErrConflictNoUpdate) {
+		t.Errorf("expected ErrConflictNoUpdate, got: %v", err)
+	}
+}
+
+func TestInsertOnConflictDoUpdateObject(t *testing.T) {
+	type User struct {
+		ID        int    `db:"id"`
+		Email     string `db:"email"`
+		Name      string `db:"name"`
+		CreatedAt string `db:"created_at" squildx:",immutable"`
+		Note      string `squildx:"note,immutable"`
+	}
+
+	u := User{ID: 1, Email: "a@b.com", Name: "Alice"}
+	q := NewInsert().Into("users").
+		ValuesObject(u).
+		OnConflictDoUpdateObject([]string{"id", "email"}, u)
+	sql, _, err := q.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "INSERT INTO users (id, email, name, created_at, note) VALUES (:id, :email, :name, :created_at, :note) ON CONFLICT (id, email) DO UPDATE SET name = EXCLUDED.name"
+	if sql != want {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", sql, want)
+	}
+}
+
+func TestInsertOnConflictDoUpdateObject_KeepsConstraintTarget(t *testing.T) {
+	type User struct {
+		Email string `db:"email"`
+		Name  string `db:"name"`
+	}
+
+	q := NewInsert().Into("users").
+		ValuesObject(User{Email: "a@b.com", Name: "Alice"}).
+		OnConflictOnConstraint("users_email_key").
+		OnConflictDoUpdateObject(nil, User{})
+	sql, _, err := q.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "INSERT INTO users (email, name) VALUES (:email, :name) ON CONFLICT ON CONSTRAINT users_email_key DO UPDATE SET email = EXCLUDED.email, name = EXCLUDED.name"
+	if sql != want {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", sql, want)
+	}
+}
+
+func TestInsertOnConflictDoUpdateObject_NotAStruct(t *testing.T) {
+	q := NewInsert().Into("users").Columns("id").
+		Values(":id", Params{"id": 1}).
+		OnConflictDoUpdateObject([]string{"id"}, 42)
+	_, _, err := q.Build()
+	if !errors.Is(err, ErrNotAStruct) {
+		t.Errorf("expected ErrNotAStruct, got: %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+package squildx
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/modfin/squildx/internal/placeholder"
+)
+
+// CopyRows returns the table, columns and row values of the INSERT, for bulk
+// loading with a COPY-style API such as pgx's CopyFrom. It fails with
+// ErrNotCopyable when the statement cannot be expressed as plain rows: with
+// ON CONFLICT, RETURNING or a SELECT, or when a row holds anything other than
+// placeholders, such as now() or DEFAULT. Audit columns are included when
+// their values are bound (see AuditPolicy.Clock). Rows are not merged into
+// one set of parameters, so rows added by ValuesObject may reuse names.
+func (b *insertBuilder) CopyRows() (table string, columns []string, rows [][]any, err error) {
+	r := newRenderer(b.paramPrefix, b.errs)
+	b.check(r)
+	if _, _, err := r.result(""); err != nil {
+		return "", nil, nil, err
+	}
+	switch {
+	case b.conflict != nil:
+		return "", nil, nil, fmt.Errorf("%w: ON CONFLICT", ErrNotCopyable)
+	case len(b.returnings) > 0:
+		return "", nil, nil, fmt.Errorf("%w: RETURNING", ErrNotCopyable)
+	case b.selectQuery != nil:
+		return "", nil, nil, fmt.Errorf("%w: INSERT ... SELECT", ErrNotCopyable)
+	}
+
+	p := resolveAudit(b.audit)
+	audit := p.values(r, []string{p.CreatedAt, p.UpdatedAt}, []string{p.CreatedBy}, b.columns)
+	if _, _, err := r.result(""); err != nil {
+		return "", nil, nil, err
+	}
+
+	columns = copySlice(b.columns)
+	auditValues := make([]any, len(audit))
+	for i, a := range audit {
+		v, err := placeholderValue(a.expr, r.params)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		columns = append(columns, a.column)
+		auditValues[i] = v
+	}
+
+	rows = make([][]any, len(b.valueRows))
+	for i, row := range b.valueRows {
+		exprs := splitTopLevel(row.sql, ',')
+		values := make([]any, 0, len(exprs)+len(audit))
+		for _, expr := range exprs {
+			v, err := placeholderValue(expr, row.params)
+			if err != nil {
+				return "", nil, nil, &BuildError{Method: row.method, Index: row.index, SQL: row.sql, Err: err}
+			}
+			values = append(values, v)
+		}
+		rows[i] = append(values, auditValues...)
+	}
+	return b.table, columns, rows, nil
+}
+
+// placeholderValue returns the value bound to expr, which must consist of a
+// single placeholder.
+func placeholderValue(expr string, params Params) (any, error) {
+	expr = strings.TrimSpace(expr)
+	phs := placeholder.Scan(expr)
+	if len(phs) != 1 || phs[0].Start != 0 || phs[0].End != len(expr) {
+		return nil, fmt.Errorf("%w: value %q is not a placeholder", ErrNotCopyable, expr)
+	}
+	return params[phs[0].Name], nil
+}
@@ -0,0 +1,135 @@
+package squildx
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestInsertCopyRows(t *testing.T) {
+	type User struct {
+		ID   int    `squildx:"id,readonly"`
+		Name string `db:"name"`
+		Age  int    `db:"age"`
+	}
+
+	table, cols, rows, err := NewInsert().
+		Into("users").
+		ValuesObject(User{Name: "Alice", Age: 30}).
+		Values(":name2, :age2", Params{"name2": "Bob", "age2": 25}).
+		CopyRows()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if table != "users" {
+		t.Errorf("table = %q, want users", table)
+	}
+	if !reflect.DeepEqual(cols, []string{"name", "age"}) {
+		t.Errorf("columns = %v", cols)
+	}
+	want := [][]any{{"Alice", 30}, {"Bob", 25}}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("rows = %v, want %v", rows, want)
+	}
+}
+
+func TestInsertCopyRows_Audit(t *testing.T) {
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	_, cols, rows, err := NewInsert().
+		Into("users").
+		Columns("name").
+		Values(":name", Params{"name": "Alice"}).
+		Audit(AuditPolicy{CreatedAt: "created_at", Clock: func() time.Time { return at }}).
+		CopyRows()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(cols, []string{"name", "created_at"}) {
+		t.Errorf("columns = %v", cols)
+	}
+	if !reflect.DeepEqual(rows, [][]any{{"Alice", at}}) {
+		t.Errorf("rows = %v", rows)
+	}
+
+	_, _, _, err = NewInsert().
+		Into("users").
+		Columns("name").
+		Values(":name", Params{"name": "Alice"}).
+		Audit(AuditPolicy{CreatedAt: "created_at"}).
+		CopyRows()
+	if !errors.Is(err, ErrNotCopyable) {
+		t.Errorf("expected ErrNotCopyable for now(), got: %v", err)
+	}
+}
+
+func TestInsertCopyRows_NotCopyable(t *testing.T) {
+	base := NewInsert().Into("users").Columns("id", "name")
+
+	tests := map[string]InsertBuilder{
+		"conflict":   base.Values(":id, :name", Params{"id": 1, "name": "A"}).OnConflictDoNothing("id"),
+		"returning":  base.Values(":id, :name", Params{"id": 1, "name": "A"}).Returning("id"),
+		"select":     base.Select(New().Select("id", "name").From("old_users")),
+		"expression": base.Values(":id, lower(:name)", Params{"id": 1, "name": "A"}),
+		"default":    base.Values(":id, DEFAULT", Params{"id": 1}),
+	}
+	for name, ins := range tests {
+		if _, _, _, err := ins.CopyRows(); !errors.Is(err, ErrNotCopyable) {
+			t.Errorf("%s: expected ErrNotCopyable, got: %v", name, err)
+		}
+	}
+}
+
+func TestInsertCopyRows_BuildError(t *testing.T) {
+	_, _, _, err := NewInsert().Columns("id").Values(":id", Params{"id": 1}).CopyRows()
+	if !errors.Is(err, ErrNoTable) {
+		t.Errorf("expected ErrNoTable, got: %v", err)
+	}
+}
+
+func TestInsertCopyRows_ValuesObjectRows(t *testing.T) {
+	type User struct {
+		Name string `db:"name"`
+		Age  int    `db:"age"`
+	}
+
+	_, cols, rows, err := NewInsert().
+		Into("users").
+		ValuesObject(User{Name: "Alice", Age: 30}).
+		ValuesObject(User{Name: "Bob", Age: 25}).
+		CopyRows()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(cols, []string{"name", "age"}) {
+		t.Errorf("columns = %v", cols)
+	}
+	want := [][]any{{"Alice", 30}, {"Bob", 25}}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("rows = %v, want %v", rows, want)
+	}
+}
+
+func TestInsertCopyRows_ErrorAttribution(t *testing.T) {
+	type User struct {
+		Name string `db:"name"`
+	}
+
+	_, _, _, err := NewInsert().
+		Into("users").
+		ValuesObject(User{Name: "Alice"}).
+		Values("lower(:name2)", Params{"name2": "Bob"}).
+		CopyRows()
+
+	var be *BuildError
+	if !errors.As(err, &be) || be.Method != "Values" || be.Index != 1 {
+		t.Errorf("expected Values #1 BuildError, got: %v", err)
+	}
+	if !errors.Is(err, ErrNotCopyable) {
+		t.Errorf("expected ErrNotCopyable, got: %v", err)
+	}
+}
@@ -8,9 +8,9 @@ func (b *insertBuilder) Returning(columns ...string) InsertBuilder {
 
 func (b *insertBuilder) ReturningObject(obj any) InsertBuilder {
 	cp := b.clone()
-	cols, err := structColumns(obj, "")
+	cols, err := structColumns(obj, "", optNested, cp.naming)
 	if err != nil {
-		cp.err = err
+		cp.failAdd("returnings", "ReturningObject", len(cp.returnings), "", err)
 		return cp
 	}
 	cp.returnings = append(cp.returnings, cols...)
@@ -9,59 +9,88 @@ func (b *insertBuilder) Values(sql string, params ...Params) InsertBuilder {
 	cp := b.clone()
 	extracted, err := extractParams(params)
 	if err != nil {
-		cp.err = err
+		cp.failAdd("valueRows", "Values", len(cp.valueRows), sql, err)
 		return cp
 	}
 	merged, prefix, err := parseParams(sql, extracted)
 	if err != nil {
-		cp.err = err
+		cp.failAdd("valueRows", "Values", len(cp.valueRows), sql, err)
 		return cp
 	}
 	if err := cp.setPrefix(prefix); err != nil {
-		cp.err = err
+		cp.failAdd("valueRows", "Values", len(cp.valueRows), sql, err)
 		return cp
 	}
-	cp.valueRows = append(cp.valueRows, paramClause{sql: sql, params: merged})
+	cp.valueRows = append(cp.valueRows, paramClause{
+		sql:    sql,
+		params: merged,
+		method: "Values",
+		index:  cp.callIndex("valueRows", len(cp.valueRows)),
+	})
 	return cp
 }
 
 func (b *insertBuilder) ValuesObject(obj any) InsertBuilder {
 	cp := b.clone()
-	cols, sql, params, err := structFieldValues(obj)
+	cols, sql, params, err := structFieldValues(obj, cp.naming)
 	if err != nil {
-		cp.err = err
+		cp.failAdd("valueRows", "ValuesObject", len(cp.valueRows), "", err)
 		return cp
 	}
 	if err := cp.setPrefix(':'); err != nil {
-		cp.err = err
+		cp.failAdd("valueRows", "ValuesObject", len(cp.valueRows), "", err)
 		return cp
 	}
 	switch {
 	case len(cp.columns) == 0:
 		cp.columns = cols
 	case !slices.Equal(cp.columns, cols):
-		cp.err = ErrColumnMismatch
+		cp.failAdd("valueRows", "ValuesObject", len(cp.valueRows), "", ErrColumnMismatch)
 		return cp
 	}
-	cp.valueRows = append(cp.valueRows, paramClause{sql: sql, params: params})
+	cp.valueRows = append(cp.valueRows, paramClause{
+		sql:    sql,
+		params: params,
+		method: "ValuesObject",
+		index:  cp.callIndex("valueRows", len(cp.valueRows)),
+	})
 	return cp
 }
 
-func structFieldValues(obj any) (columns []string, sql string, params Params, err error) {
-	columns, err = structColumns(obj, "")
+// structFieldValues derives the INSERT columns, the VALUES row and its params
+// from obj. Fields tagged readonly or omitinsert are left out, as are zero
+// omitempty fields; zero default fields are rendered as DEFAULT.
+func structFieldValues(obj any, ns NamingStrategy) (columns []string, sql string, params Params, err error) {
+	v, err := structValue(obj)
 	if err != nil {
 		return nil, "", nil, err
 	}
 
-	params, err = structValues(obj)
-	if err != nil {
-		return nil, "", nil, err
-	}
-
-	placeholders := make([]string, len(columns))
-	for i, col := range columns {
-		placeholders[i] = ":" + col
+	fields := structFields(v.Type(), ns)
+	columns = make([]string, 0, len(fields))
+	placeholders := make([]string, 0, len(fields))
+	params = make(Params, len(fields))
+	for _, f := range fields {
+		if f.opts.has(optReadonly | optOmitInsert | optNested) {
+			continue
+		}
+		fv, ok := f.value(v)
+		zero := !ok || fv.IsZero()
+		switch {
+		case zero && f.opts.has(optOmitEmpty):
+			continue
+		case zero && f.opts.has(optDefault):
+			columns = append(columns, f.name)
+			placeholders = append(placeholders, "DEFAULT")
+			continue
+		}
+		columns = append(columns, f.name)
+		placeholders = append(placeholders, f.placeholder)
+		if ok {
+			params[f.name] = indirect(fv)
+		} else {
+			params[f.name] = nil
+		}
 	}
-	sql = strings.Join(placeholders, ", ")
-	return columns, sql, params, nil
+	return columns, strings.Join(placeholders, ", "), params, nil
 }
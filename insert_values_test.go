@@ -134,3 +134,95 @@ func TestInsertValuesObject_EmbeddedStruct(t *testing.T) {
 	assertParam(t, ib.valueRows[0].params, "id", 1)
 	assertParam(t, ib.valueRows[0].params, "name", "Alice")
 }
+
+func TestInsertValuesObject_TagOptions(t *testing.T) {
+	type User struct {
+		ID        int    `squildx:"id,pk,readonly"`
+		Name      string `db:"name"`
+		Nickname  string `squildx:"nickname,omitempty"`
+		Role      string `squildx:"role,default"`
+		Search    string `squildx:"search,omitinsert"`
+		CreatedAt string `squildx:"created_at,immutable"`
+	}
+
+	q, params, err := NewInsert().
+		Into("users").
+		ValuesObject(User{ID: 7, Name: "Alice", Search: "alice"}).
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "INSERT INTO users (name, role, created_at) VALUES (:name, DEFAULT, :created_at)"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+	assertParam(t, params, "name", "Alice")
+	if len(params) != 2 {
+		t.Errorf("expected 2 params, got %d: %v", len(params), params)
+	}
+}
+
+func TestInsertValuesObject_NonZeroOptionalFields(t *testing.T) {
+	type User struct {
+		Name     string `db:"name"`
+		Nickname string `squildx:"nickname,omitempty"`
+		Role     string `squildx:"role,default"`
+	}
+
+	q, params, err := NewInsert().
+		Into("users").
+		ValuesObject(User{Name: "Alice", Nickname: "Al", Role: "admin"}).
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "INSERT INTO users (name, nickname, role) VALUES (:name, :nickname, :role)"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+	assertParam(t, params, "nickname", "Al")
+	assertParam(t, params, "role", "admin")
+}
+
+func TestInsertValuesObject_OmitEmptyColumnMismatch(t *testing.T) {
+	type User struct {
+		Name     string `db:"name"`
+		Nickname string `squildx:"nickname,omitempty"`
+	}
+
+	_, _, err := NewInsert().
+		Into("users").
+		ValuesObject(User{Name: "Alice", Nickname: "Al"}).
+		ValuesObject(User{Name: "Bob"}).
+		Build()
+
+	if !errors.Is(err, ErrColumnMismatch) {
+		t.Errorf("expected ErrColumnMismatch, got: %v", err)
+	}
+}
+
+func TestInsertValuesObject_DefaultKeepsRowsAligned(t *testing.T) {
+	type User struct {
+		Name string `db:"name"`
+		Role string `squildx:"role,default"`
+	}
+
+	q, _, err := NewInsert().
+		Into("users").
+		ValuesObject(User{Name: "Alice", Role: "admin"}).
+		ValuesObject(User{Name: "Alice"}).
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "INSERT INTO users (name, role) VALUES (:name, :role), (:name, DEFAULT)"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+}
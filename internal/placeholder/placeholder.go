@@ -0,0 +1,58 @@
+// Package placeholder implements the named-placeholder scanning rules shared by
+// squildx and its tooling.
+package placeholder
+
+import (
+	"regexp"
+	"strings"
+)
+
+var regex = regexp.MustCompile(`[:@][a-zA-Z_][a-zA-Z0-9_]*`)
+
+// Placeholder is a named placeholder found in an SQL fragment.
+type Placeholder struct {
+	Name   string
+	Prefix byte // ':' or '@'
+	Start  int  // byte offset of the prefix
+	End    int  // byte offset just past the name
+}
+
+// Scan returns the placeholders in sql in order of appearance.
+//
+// It skips doubled-prefix sequences (:: and @@) so that PostgreSQL type casts
+// (value::integer) and session variables (@@var) are not treated as parameters.
+func Scan(sql string) []Placeholder {
+	indices := regex.FindAllStringIndex(sql, -1)
+	var out []Placeholder
+	for _, idx := range indices {
+		// Skip doubled-prefix: e.g. :: in "value::integer" or @@ in "@@session_var"
+		if idx[0] > 0 && sql[idx[0]-1] == sql[idx[0]] {
+			continue
+		}
+		out = append(out, Placeholder{
+			Name:   sql[idx[0]+1 : idx[1]],
+			Prefix: sql[idx[0]],
+			Start:  idx[0],
+			End:    idx[1],
+		})
+	}
+	return out
+}
+
+// Rewrite returns sql with each placeholder, including its prefix, replaced by
+// the result of repl.
+func Rewrite(sql string, repl func(Placeholder) string) string {
+	phs := Scan(sql)
+	if len(phs) == 0 {
+		return sql
+	}
+	var sb strings.Builder
+	last := 0
+	for _, ph := range phs {
+		sb.WriteString(sql[last:ph.Start])
+		sb.WriteString(repl(ph))
+		last = ph.End
+	}
+	sb.WriteString(sql[last:])
+	return sb.String()
+}
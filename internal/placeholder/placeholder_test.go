@@ -0,0 +1,44 @@
+package placeholder
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScan(t *testing.T) {
+	tests := []struct {
+		sql  string
+		want []Placeholder
+	}{
+		{"id = :id", []Placeholder{{Name: "id", Prefix: ':', Start: 5, End: 8}}},
+		{"id = @id AND name = @name", []Placeholder{
+			{Name: "id", Prefix: '@', Start: 5, End: 8},
+			{Name: "name", Prefix: '@', Start: 20, End: 25},
+		}},
+		{"value::integer = :v", []Placeholder{{Name: "v", Prefix: ':', Start: 17, End: 19}}},
+		{"@@session_var", nil},
+		{"no placeholders", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sql, func(t *testing.T) {
+			got := Scan(tt.sql)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Scan(%q) = %+v, want %+v", tt.sql, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	got := Rewrite("a = :a AND b::int = :b OR c = :a", func(ph Placeholder) string {
+		return "@" + ph.Name
+	})
+	want := "a = @a AND b::int = @b OR c = @a"
+	if got != want {
+		t.Errorf("Rewrite = %q, want %q", got, want)
+	}
+
+	if got := Rewrite("no placeholders", nil); got != "no placeholders" {
+		t.Errorf("Rewrite changed SQL without placeholders: %q", got)
+	}
+}
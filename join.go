@@ -15,6 +15,28 @@ const (
 	crossJoinLateral joinType = "CROSS JOIN LATERAL"
 )
 
+// method names the builder method that adds a join of this type.
+func (jt joinType) method() string {
+	switch jt {
+	case innerJoin:
+		return "InnerJoin"
+	case leftJoin:
+		return "LeftJoin"
+	case rightJoin:
+		return "RightJoin"
+	case fullJoin:
+		return "FullJoin"
+	case crossJoin:
+		return "CrossJoin"
+	case innerJoinLateral:
+		return "InnerJoinLateral"
+	case leftJoinLateral:
+		return "LeftJoinLateral"
+	default:
+		return "CrossJoinLateral"
+	}
+}
+
 type joinClause struct {
 	joinType joinType
 	clause   paramClause
@@ -26,16 +48,16 @@ func (b *builder) addJoin(jt joinType, sql string, maps []Params) *builder {
 	cp := b.clone()
 	p, err := extractParams(maps)
 	if err != nil {
-		cp.err = err
+		cp.failAdd("joins", jt.method(), len(cp.joins), sql, err)
 		return cp
 	}
 	params, prefix, err := parseParams(sql, p)
 	if err != nil {
-		cp.err = err
+		cp.failAdd("joins", jt.method(), len(cp.joins), sql, err)
 		return cp
 	}
 	if err := cp.setPrefix(prefix); err != nil {
-		cp.err = err
+		cp.failAdd("joins", jt.method(), len(cp.joins), sql, err)
 		return cp
 	}
 	for _, j := range cp.joins {
@@ -45,12 +67,17 @@ func (b *builder) addJoin(jt joinType, sql string, maps []Params) *builder {
 		if paramsEqual(j.clause.params, params) {
 			return cp
 		}
-		cp.err = fmt.Errorf("%w: %s %s", ErrDuplicateJoin, jt, sql)
+		cp.failAdd("joins", jt.method(), len(cp.joins), sql, fmt.Errorf("%w: %s %s", ErrDuplicateJoin, jt, sql))
 		return cp
 	}
 	cp.joins = append(cp.joins, joinClause{
 		joinType: jt,
-		clause:   paramClause{sql: sql, params: params},
+		clause: paramClause{
+			sql:    sql,
+			params: params,
+			method: jt.method(),
+			index:  cp.callIndex("joins", len(cp.joins)),
+		},
 	})
 	return cp
 }
@@ -79,16 +106,16 @@ func (b *builder) addJoinLateral(jt joinType, sub Builder, alias string, on stri
 	cp := b.clone()
 	p, err := extractParams(maps)
 	if err != nil {
-		cp.err = err
+		cp.failAdd("joins", jt.method(), len(cp.joins), on, err)
 		return cp
 	}
 	params, prefix, err := parseParams(on, p)
 	if err != nil {
-		cp.err = err
+		cp.failAdd("joins", jt.method(), len(cp.joins), on, err)
 		return cp
 	}
 	if err := cp.setPrefix(prefix); err != nil {
-		cp.err = err
+		cp.failAdd("joins", jt.method(), len(cp.joins), on, err)
 		return cp
 	}
 	for _, j := range cp.joins {
@@ -98,12 +125,17 @@ func (b *builder) addJoinLateral(jt joinType, sub Builder, alias string, on stri
 		if j.clause.sql == on && paramsEqual(j.clause.params, params) && buildersEqual(j.subQuery, sub) {
 			return cp
 		}
-		cp.err = fmt.Errorf("%w: %s LATERAL %s", ErrDuplicateJoin, jt, alias)
+		cp.failAdd("joins", jt.method(), len(cp.joins), on, fmt.Errorf("%w: %s LATERAL %s", ErrDuplicateJoin, jt, alias))
 		return cp
 	}
 	cp.joins = append(cp.joins, joinClause{
 		joinType: jt,
-		clause:   paramClause{sql: on, params: params},
+		clause: paramClause{
+			sql:    on,
+			params: params,
+			method: jt.method(),
+			index:  cp.callIndex("joins", len(cp.joins)),
+		},
 		subQuery: sub,
 		alias:    alias,
 	})
@@ -5,3 +5,10 @@ func (b *builder) Limit(n uint64) Builder {
 	cp.limit = &n
 	return cp
 }
+
+// ClearLimit removes the LIMIT.
+func (b *builder) ClearLimit() Builder {
+	cp := b.clone()
+	cp.limit = nil
+	return cp
+}
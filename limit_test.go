@@ -15,3 +15,15 @@ func TestLimitZero(t *testing.T) {
 		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
 	}
 }
+
+func TestClearLimit(t *testing.T) {
+	q, _, err := New().Select("*").From("users").Limit(10).Offset(5).ClearLimit().Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "SELECT * FROM users OFFSET 5"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+}
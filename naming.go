@@ -0,0 +1,119 @@
+package squildx
+
+import (
+	"sync/atomic"
+	"unicode"
+)
+
+// NamingStrategy derives a column name from a Go struct field name. It is used
+// by every *Object method for fields without a squildx, db or json tag name.
+type NamingStrategy interface {
+	ColumnName(field string) string
+}
+
+// NamingFunc adapts an ordinary function to a NamingStrategy. Function values
+// are not comparable, so struct metadata for them is not cached; prefer a
+// comparable type for strategies used on hot paths.
+type NamingFunc func(field string) string
+
+func (f NamingFunc) ColumnName(field string) string {
+	return f(field)
+}
+
+var (
+	// SnakeCase maps FirstName to first_name. It is the default strategy.
+	SnakeCase NamingStrategy = snakeCase{}
+	// CamelCase maps FirstName to firstName and UserID to userID.
+	CamelCase NamingStrategy = camelCase{}
+	// Identity uses the field name unchanged.
+	Identity NamingStrategy = identity{}
+)
+
+type snakeCase struct{}
+
+func (snakeCase) ColumnName(field string) string { return toSnakeCase(field) }
+
+type camelCase struct{}
+
+func (camelCase) ColumnName(field string) string { return toCamelCase(field) }
+
+type identity struct{}
+
+func (identity) ColumnName(field string) string { return field }
+
+// Prefixed returns a strategy that prepends prefix to the names produced by base,
+// for schemas where every column of a table shares a prefix (usr_id, usr_name).
+func Prefixed(prefix string, base NamingStrategy) NamingStrategy {
+	return prefixed{prefix: prefix, base: base}
+}
+
+type prefixed struct {
+	prefix string
+	base   NamingStrategy
+}
+
+func (p prefixed) ColumnName(field string) string { return p.prefix + p.base.ColumnName(field) }
+
+type namingHolder struct{ ns NamingStrategy }
+
+var globalNaming atomic.Pointer[namingHolder]
+
+// SetNamingStrategy sets the strategy used by builders that have not been
+// given one with Naming. Passing nil restores SnakeCase.
+func SetNamingStrategy(ns NamingStrategy) {
+	if ns == nil {
+		ns = SnakeCase
+	}
+	globalNaming.Store(&namingHolder{ns: ns})
+}
+
+// Naming sets the strategy used by subsequent *Object calls on this builder.
+func (b *builder) Naming(ns NamingStrategy) Builder {
+	cp := b.clone()
+	cp.naming = ns
+	return cp
+}
+
+func (b *insertBuilder) Naming(ns NamingStrategy) InsertBuilder {
+	cp := b.clone()
+	cp.naming = ns
+	return cp
+}
+
+func (b *updateBuilder) Naming(ns NamingStrategy) UpdateBuilder {
+	cp := b.clone()
+	cp.naming = ns
+	return cp
+}
+
+func (b *deleteBuilder) Naming(ns NamingStrategy) DeleteBuilder {
+	cp := b.clone()
+	cp.naming = ns
+	return cp
+}
+
+// resolveNaming returns ns, or the global strategy when ns is nil.
+func resolveNaming(ns NamingStrategy) NamingStrategy {
+	if ns != nil {
+		return ns
+	}
+	if h := globalNaming.Load(); h != nil {
+		return h.ns
+	}
+	return SnakeCase
+}
+
+func toCamelCase(s string) string {
+	runes := []rune(s)
+	for i, r := range runes {
+		if !unicode.IsUpper(r) {
+			break
+		}
+		// Keep the last capital of a leading acronym when it starts the next word: HTTPCode -> httpCode.
+		if i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+			break
+		}
+		runes[i] = unicode.ToLower(r)
+	}
+	return string(runes)
+}
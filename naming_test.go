@@ -0,0 +1,137 @@
+package squildx
+
+import "testing"
+
+func TestToCamelCase(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"FirstName", "firstName"},
+		{"ID", "id"},
+		{"UserID", "userID"},
+		{"HTTPCode", "httpCode"},
+		{"URL", "url"},
+		{"already", "already"},
+		{"A", "a"},
+		{"S3Bucket", "s3Bucket"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := toCamelCase(tt.input); got != tt.want {
+				t.Errorf("toCamelCase(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNamingStrategies(t *testing.T) {
+	tests := []struct {
+		name string
+		ns   NamingStrategy
+		want string
+	}{
+		{"snake", SnakeCase, "created_at"},
+		{"camel", CamelCase, "createdAt"},
+		{"identity", Identity, "CreatedAt"},
+		{"prefixed", Prefixed("usr_", SnakeCase), "usr_created_at"},
+		{"func", NamingFunc(func(s string) string { return "x_" + s }), "x_CreatedAt"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.ns.ColumnName("CreatedAt"); got != tt.want {
+				t.Errorf("ColumnName = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+type namingUser struct {
+	UserID    int `squildx:"user_id,pk"`
+	FirstName string
+	LastName  string
+}
+
+func TestNaming_PerBuilder(t *testing.T) {
+	q, _, err := New().Naming(CamelCase).SelectObject(namingUser{}, "u").From("users u").Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "SELECT u.user_id, u.firstName, u.lastName FROM users u"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+
+	q, params, err := NewInsert().Naming(Identity).Into("users").ValuesObject(namingUser{UserID: 1, FirstName: "Alice"}).Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected = "INSERT INTO users (user_id, FirstName, LastName) VALUES (:user_id, :FirstName, :LastName)"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+	assertParam(t, params, "FirstName", "Alice")
+
+	q, _, err = NewUpdate().Naming(Prefixed("usr_", SnakeCase)).Table("users").SetObject(namingUser{UserID: 1}).Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected = "UPDATE users SET usr_first_name = :usr_first_name, usr_last_name = :usr_last_name WHERE user_id = :user_id"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+
+	q, _, err = NewDelete().Naming(CamelCase).From("users").Where("user_id = 1").ReturningObject(namingUser{}).Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected = "DELETE FROM users WHERE user_id = 1 RETURNING user_id, firstName, lastName"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+}
+
+func TestNaming_FuncStrategyNotCached(t *testing.T) {
+	upper := NamingFunc(func(s string) string { return "U_" + s })
+	for range 2 {
+		q, _, err := New().Naming(upper).SelectObject(namingUser{}).From("users").Build()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := "SELECT user_id, U_FirstName, U_LastName FROM users"
+		if q != expected {
+			t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+		}
+	}
+}
+
+func TestNaming_Global(t *testing.T) {
+	SetNamingStrategy(CamelCase)
+	t.Cleanup(func() { SetNamingStrategy(nil) })
+
+	q, _, err := New().SelectObject(namingUser{}).From("users").Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "SELECT user_id, firstName, lastName FROM users"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+
+	q, _, err = New().Naming(SnakeCase).SelectObject(namingUser{}).From("users").Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected = "SELECT user_id, first_name, last_name FROM users"
+	if q != expected {
+		t.Errorf("per-builder strategy should win over the global one\n got: %s\nwant: %s", q, expected)
+	}
+}
+
+func TestNaming_Immutability(t *testing.T) {
+	base := New()
+	_ = base.Naming(CamelCase)
+	if base.(*builder).naming != nil {
+		t.Error("base naming strategy mutated")
+	}
+}
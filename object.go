@@ -0,0 +1,337 @@
+package squildx
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// tagOptions is the set of options following the column name in a squildx
+// struct tag, e.g. `squildx:"id,pk,readonly"`.
+type tagOptions uint16
+
+const (
+	optPK         tagOptions = 1 << iota // primary key: excluded from SetObject and used for its WHERE clause
+	optReadonly                          // never written by INSERT or UPDATE
+	optOmitInsert                        // never written by INSERT
+	optOmitEmpty                         // left out of ValuesObject when it holds the zero value
+	optDefault                           // rendered as DEFAULT by ValuesObject when it holds the zero value
+	optImmutable                         // never updated, neither by SetObject nor by an upsert
+	optPrefix                            // nested struct whose columns are selected as "name.column"
+	optVersion                           // optimistic lock: incremented by SetObject, which also matches its current value
+
+	// optNested marks fields of a prefix struct. They belong to another table,
+	// so they are only ever selected, never written or returned.
+	optNested
+)
+
+var tagOptionNames = map[string]tagOptions{
+	"pk":         optPK,
+	"readonly":   optReadonly,
+	"omitinsert": optOmitInsert,
+	"omitempty":  optOmitEmpty,
+	"default":    optDefault,
+	"immutable":  optImmutable,
+	"prefix":     optPrefix,
+	"version":    optVersion,
+}
+
+// has reports whether any of the options in mask are set.
+func (o tagOptions) has(mask tagOptions) bool {
+	return o&mask != 0
+}
+
+// structField describes an exported struct field that maps to a column.
+type structField struct {
+	name        string
+	index       []int // index path for reflect.Value.FieldByIndex, through embedded structs
+	opts        tagOptions
+	path        string // scan prefix of a nested field, e.g. "user."
+	table       string // table qualifier of a nested field
+	placeholder string // ":name"
+	assignment  string // "name = :name"
+}
+
+// structMeta is the reflection metadata of a struct type, computed once and
+// shared by every struct helper.
+type structMeta struct {
+	fields  []structField
+	columns sync.Map // columnsKey -> []string
+}
+
+type columnsKey struct {
+	table  string
+	prefix string
+	skip   tagOptions
+}
+
+type structKey struct {
+	typ    reflect.Type
+	naming NamingStrategy
+}
+
+var structCache sync.Map // structKey -> *structMeta
+
+// structMetaOf returns the metadata of t with untagged columns named by ns,
+// or by the global strategy when ns is nil.
+func structMetaOf(t reflect.Type, ns NamingStrategy) *structMeta {
+	ns = resolveNaming(ns)
+	// Strategies whose dynamic type is not comparable, such as NamingFunc,
+	// cannot be part of a cache key.
+	if !reflect.ValueOf(ns).Comparable() {
+		var fields []structField
+		collectFields(t, ns, nil, structField{}, &fields)
+		return &structMeta{fields: fields}
+	}
+
+	key := structKey{typ: t, naming: ns}
+	if m, ok := structCache.Load(key); ok {
+		return m.(*structMeta)
+	}
+	var fields []structField
+	collectFields(t, ns, nil, structField{}, &fields)
+	m, _ := structCache.LoadOrStore(key, &structMeta{fields: fields})
+	return m.(*structMeta)
+}
+
+// columnNames returns the column names of the struct, qualified with table
+// when it is non-empty and without fields carrying any of the skip options.
+// With a prefix, or for nested fields, each column is aliased to the dotted
+// name sqlx scans into nested structs: u.id AS "user.id".
+// The returned slice is cached and must not be modified.
+func (m *structMeta) columnNames(table, prefix string, skip tagOptions) []string {
+	key := columnsKey{table: table, prefix: prefix, skip: skip}
+	if cols, ok := m.columns.Load(key); ok {
+		return cols.([]string)
+	}
+	if prefix != "" {
+		prefix += "."
+	}
+	cols := make([]string, 0, len(m.fields))
+	for _, f := range m.fields {
+		if f.opts.has(skip) {
+			continue
+		}
+		qualifier := table
+		if f.opts.has(optNested) {
+			qualifier = f.table
+		}
+		name := f.name
+		if qualifier != "" {
+			name = qualifier + "." + name
+		}
+		if alias := prefix + f.path; alias != "" {
+			name += ` AS "` + alias + f.name + `"`
+		}
+		cols = append(cols, name)
+	}
+	actual, _ := m.columns.LoadOrStore(key, cols[:len(cols):len(cols)])
+	return actual.([]string)
+}
+
+// structType dereferences the type of obj, which must be a struct or a
+// (possibly nil) pointer to one.
+func structType(obj any) (reflect.Type, error) {
+	t := reflect.TypeOf(obj)
+	if t == nil {
+		return nil, ErrNotAStruct
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, ErrNotAStruct
+	}
+	return t, nil
+}
+
+// structValue dereferences obj, which must be a struct or a non-nil pointer to one.
+func structValue(obj any) (reflect.Value, error) {
+	v := reflect.ValueOf(obj)
+	if !v.IsValid() {
+		return reflect.Value{}, ErrNotAStruct
+	}
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Value{}, ErrNotAStruct
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, ErrNotAStruct
+	}
+	return v, nil
+}
+
+// structFields lists the column fields of struct type t in declaration order.
+// Untagged anonymous struct fields are flattened into their parent. The
+// returned slice is cached and must not be modified.
+func structFields(t reflect.Type, ns NamingStrategy) []structField {
+	return structMetaOf(t, ns).fields
+}
+
+// collectFields appends the column fields of t to fields. For fields of a
+// prefix struct, nest carries the options, scan path and table qualifier
+// inherited from the enclosing struct field.
+func collectFields(t reflect.Type, ns NamingStrategy, index []int, nest structField, fields *[]structField) {
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		ft := f.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+
+		tagName := fieldTagName(f)
+		if tagName == "-" {
+			continue
+		}
+
+		idx := append(copySlice(index), i)
+
+		if f.Anonymous && ft.Kind() == reflect.Struct && tagName == "" {
+			collectFields(ft, ns, idx, nest, fields)
+			continue
+		}
+
+		name := tagName
+		if name == "" {
+			name = ns.ColumnName(f.Name)
+		}
+		opts := fieldOptions(f) | nest.opts
+
+		if ft.Kind() == reflect.Struct && opts.has(optPrefix) {
+			table := fieldOptionValue(f, "table")
+			if table == "" {
+				table = name
+			}
+			inner := structField{opts: optNested, path: nest.path + name + ".", table: table}
+			collectFields(ft, ns, idx, inner, fields)
+			continue
+		}
+
+		*fields = append(*fields, structField{
+			name:        name,
+			index:       idx,
+			opts:        opts,
+			path:        nest.path,
+			table:       nest.table,
+			placeholder: ":" + name,
+			assignment:  name + " = :" + name,
+		})
+	}
+}
+
+// value returns the field of v, dereferencing non-nil pointers. The bool is
+// false when an embedded struct pointer on the way to the field is nil.
+func (sf structField) value(v reflect.Value) (reflect.Value, bool) {
+	for i, x := range sf.index {
+		if i > 0 {
+			for v.Kind() == reflect.Ptr {
+				if v.IsNil() {
+					return reflect.Value{}, false
+				}
+				v = v.Elem()
+			}
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// addr returns a pointer to the field of v, which must be addressable,
+// allocating nil struct pointers on the way to the field.
+func (sf structField) addr(v reflect.Value) any {
+	for i, x := range sf.index {
+		if i > 0 {
+			for v.Kind() == reflect.Ptr {
+				if v.IsNil() {
+					v.Set(reflect.New(v.Type().Elem()))
+				}
+				v = v.Elem()
+			}
+		}
+		v = v.Field(x)
+	}
+	return v.Addr().Interface()
+}
+
+// indirect dereferences non-nil pointers so drivers receive the underlying value.
+func indirect(v reflect.Value) any {
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	return v.Interface()
+}
+
+func fieldTagName(f reflect.StructField) string {
+	for _, tag := range []string{"squildx", "db", "json"} {
+		v, ok := f.Tag.Lookup(tag)
+		if !ok {
+			continue
+		}
+		v, _, _ = strings.Cut(v, ",")
+		if v == "-" {
+			return "-"
+		}
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// fieldOptionValue returns the value of a key=value option in the squildx tag
+// of f, e.g. "u" for `squildx:"user,prefix,table=u"`.
+func fieldOptionValue(f reflect.StructField, key string) string {
+	_, rest, _ := strings.Cut(f.Tag.Get("squildx"), ",")
+	for rest != "" {
+		var opt string
+		opt, rest, _ = strings.Cut(rest, ",")
+		if v, ok := strings.CutPrefix(opt, key+"="); ok {
+			return v
+		}
+	}
+	return ""
+}
+
+// fieldOptions parses the squildx tag options of f. Options in db and json
+// tags belong to other libraries and are ignored, as are unknown options.
+func fieldOptions(f reflect.StructField) tagOptions {
+	_, rest, _ := strings.Cut(f.Tag.Get("squildx"), ",")
+	var opts tagOptions
+	for rest != "" {
+		var name string
+		name, rest, _ = strings.Cut(rest, ",")
+		opts |= tagOptionNames[name]
+	}
+	return opts
+}
+
+// structKeys returns a "col = :col" clause for each primary key field of obj.
+// It fails with ErrNoPrimaryKey when obj has no pk field or a key is nil.
+func structKeys(obj any, ns NamingStrategy) ([]paramClause, error) {
+	v, err := structValue(obj)
+	if err != nil {
+		return nil, err
+	}
+	var keys []paramClause
+	for _, f := range structFields(v.Type(), ns) {
+		if !f.opts.has(optPK) || f.opts.has(optNested) {
+			continue
+		}
+		fv, ok := f.value(v)
+		if !ok || (fv.Kind() == reflect.Ptr && fv.IsNil()) {
+			return nil, fmt.Errorf("%w: %s is nil", ErrNoPrimaryKey, f.name)
+		}
+		keys = append(keys, paramClause{sql: f.assignment, params: Params{f.name: indirect(fv)}})
+	}
+	if len(keys) == 0 {
+		return nil, ErrNoPrimaryKey
+	}
+	return keys, nil
+}
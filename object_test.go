@@ -0,0 +1,161 @@
+package squildx
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFieldOptions(t *testing.T) {
+	tests := []struct {
+		tag  reflect.StructTag
+		want tagOptions
+	}{
+		{``, 0},
+		{`squildx:"id"`, 0},
+		{`squildx:"id,pk"`, optPK},
+		{`squildx:"id,pk,readonly"`, optPK | optReadonly},
+		{`squildx:",omitempty,default"`, optOmitEmpty | optDefault},
+		{`squildx:"id,unknown"`, 0},
+		{`db:"id,omitempty" json:"id,omitempty"`, 0},
+	}
+	for _, tt := range tests {
+		f := reflect.StructField{Name: "ID", Tag: tt.tag}
+		if got := fieldOptions(f); got != tt.want {
+			t.Errorf("fieldOptions(%s) = %b, want %b", tt.tag, got, tt.want)
+		}
+	}
+}
+
+func TestStructFields(t *testing.T) {
+	type Base struct {
+		ID int `squildx:"id,pk"`
+	}
+	type User struct {
+		*Base
+		Name    string `db:"name"`
+		Ignored string `db:"-"`
+		Email   string `json:"email,omitempty"`
+	}
+
+	fields := structFields(reflect.TypeOf(User{}), nil)
+	want := []structField{
+		{name: "id", index: []int{0, 0}, opts: optPK, placeholder: ":id", assignment: "id = :id"},
+		{name: "name", index: []int{1}, placeholder: ":name", assignment: "name = :name"},
+		{name: "email", index: []int{3}, placeholder: ":email", assignment: "email = :email"},
+	}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("structFields = %+v, want %+v", fields, want)
+	}
+}
+
+func TestStructFieldValue_NilEmbeddedPointer(t *testing.T) {
+	type Base struct {
+		ID int
+	}
+	type User struct {
+		*Base
+	}
+
+	f := structFields(reflect.TypeOf(User{}), nil)[0]
+	if _, ok := f.value(reflect.ValueOf(User{})); ok {
+		t.Error("expected no value through a nil embedded pointer")
+	}
+	v, ok := f.value(reflect.ValueOf(User{Base: &Base{ID: 5}}))
+	if !ok || v.Interface() != 5 {
+		t.Errorf("value = %v, %v; want 5, true", v, ok)
+	}
+}
+
+func TestStructMetaCached(t *testing.T) {
+	type User struct {
+		ID   int    `db:"id"`
+		Name string `db:"name"`
+	}
+
+	typ := reflect.TypeOf(User{})
+	if structMetaOf(typ, nil) != structMetaOf(reflect.TypeOf(&User{}).Elem(), SnakeCase) {
+		t.Error("expected the same metadata for the same type")
+	}
+
+	cols := structMetaOf(typ, nil).columnNames("u", "", 0)
+	want := []string{"u.id", "u.name"}
+	if !reflect.DeepEqual(cols, want) {
+		t.Errorf("columnNames = %v, want %v", cols, want)
+	}
+	if cap(cols) != len(cols) {
+		t.Errorf("cached columns must not have spare capacity, cap = %d", cap(cols))
+	}
+}
+
+func TestStructMetaConcurrent(t *testing.T) {
+	type Row struct {
+		A, B, C int
+	}
+
+	var wg sync.WaitGroup
+	for range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cols, err := structColumns(Row{}, "r", 0, nil)
+			if err != nil || len(cols) != 3 {
+				t.Errorf("structColumns = %v, %v", cols, err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+type benchUser struct {
+	ID        int       `squildx:"id,pk"`
+	FirstName string    `db:"first_name"`
+	LastName  string    `db:"last_name"`
+	Email     string    `db:"email"`
+	Active    bool      `db:"active"`
+	CreatedAt time.Time `squildx:"created_at,readonly"`
+}
+
+func BenchmarkStructFields(b *testing.B) {
+	typ := reflect.TypeOf(benchUser{})
+	b.Run("cached", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_ = structFields(typ, nil)
+		}
+	})
+	b.Run("uncached", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			var fields []structField
+			collectFields(typ, SnakeCase, nil, structField{}, &fields)
+		}
+	})
+}
+
+func BenchmarkSelectObject(b *testing.B) {
+	base := New().From("users u")
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = base.SelectObject(benchUser{}, "u")
+	}
+}
+
+func BenchmarkValuesObject(b *testing.B) {
+	base := NewInsert().Into("users")
+	u := benchUser{ID: 1, FirstName: "Alice", LastName: "Smith", Email: "a@b.com", Active: true}
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = base.ValuesObject(u)
+	}
+}
+
+func BenchmarkSetObject(b *testing.B) {
+	base := NewUpdate().Table("users")
+	u := benchUser{ID: 1, FirstName: "Alice", LastName: "Smith", Email: "a@b.com", Active: true}
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = base.SetObject(u)
+	}
+}
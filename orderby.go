@@ -4,18 +4,31 @@ func (b *builder) OrderBy(expr string, params ...Params) Builder {
 	cp := b.clone()
 	p, err := extractParams(params)
 	if err != nil {
-		cp.err = err
+		cp.failAdd("orderBys", "OrderBy", len(cp.orderBys), expr, err)
 		return cp
 	}
 	parsed, prefix, err := parseParams(expr, p)
 	if err != nil {
-		cp.err = err
+		cp.failAdd("orderBys", "OrderBy", len(cp.orderBys), expr, err)
 		return cp
 	}
 	if err := cp.setPrefix(prefix); err != nil {
-		cp.err = err
+		cp.failAdd("orderBys", "OrderBy", len(cp.orderBys), expr, err)
 		return cp
 	}
-	cp.orderBys = append(cp.orderBys, paramClause{sql: expr, params: parsed})
+	cp.orderBys = append(cp.orderBys, paramClause{
+		sql:    expr,
+		params: parsed,
+		method: "OrderBy",
+		index:  cp.callIndex("orderBys", len(cp.orderBys)),
+	})
+	return cp
+}
+
+// ClearOrderBy removes all ORDER BY expressions.
+func (b *builder) ClearOrderBy() Builder {
+	cp := b.clone()
+	cp.orderBys = nil
+	cp.resetPrefix()
 	return cp
 }
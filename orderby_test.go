@@ -139,3 +139,24 @@ func TestOrderByWithWhere(t *testing.T) {
 		t.Errorf("param 'query_vec' mismatch: got %v, want %v", params["query_vec"], vec)
 	}
 }
+
+func TestClearOrderBy(t *testing.T) {
+	q, params, err := New().Select("id").
+		From("documents").
+		OrderBy("similarity(embedding, :query_vec) DESC", Params{"query_vec": "v"}).
+		ClearOrderBy().
+		OrderBy("id").
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "SELECT id FROM documents ORDER BY id"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+	if len(params) != 0 {
+		t.Errorf("expected no params, got %v", params)
+	}
+}
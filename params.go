@@ -2,10 +2,9 @@ package squildx
 
 import (
 	"fmt"
-	"regexp"
-)
 
-var paramRegex = regexp.MustCompile(`[:@][a-zA-Z_][a-zA-Z0-9_]*`)
+	"github.com/modfin/squildx/internal/placeholder"
+)
 
 // extractParams merges the variadic Params slices into a single map.
 // Duplicate keys with different values produce ErrDuplicateParam.
@@ -29,29 +28,20 @@ func extractParams(maps []Params) (Params, error) {
 // provided params map, and returns the validated params plus the detected prefix
 // byte (':' or '@', or 0 if no placeholders were found).
 //
-// It skips doubled-prefix sequences (:: and @@) so that PostgreSQL type casts
-// (value::integer) and session variables (@@var) are not treated as parameters.
+// Placeholders are found with placeholder.Scan, which skips doubled-prefix
+// sequences (:: and @@) so that PostgreSQL type casts (value::integer) and
+// session variables (@@var) are not treated as parameters.
 func parseParams(sql string, params Params) (Params, byte, error) {
-	indices := paramRegex.FindAllStringIndex(sql, -1)
-
 	var prefix byte
 	placeholders := make(map[string]struct{})
-	for _, idx := range indices {
-		// Skip doubled-prefix: e.g. :: in "value::integer" or @@ in "@@session_var"
-		if idx[0] > 0 && sql[idx[0]-1] == sql[idx[0]] {
-			continue
-		}
-
-		p := sql[idx[0]]
+	for _, ph := range placeholder.Scan(sql) {
 		if prefix == 0 {
-			prefix = p
+			prefix = ph.Prefix
 		}
-		if p != prefix {
+		if ph.Prefix != prefix {
 			return nil, 0, ErrMixedPrefix
 		}
-
-		name := sql[idx[0]+1 : idx[1]]
-		placeholders[name] = struct{}{}
+		placeholders[ph.Name] = struct{}{}
 	}
 
 	if len(placeholders) == 0 && len(params) == 0 {
@@ -93,12 +83,8 @@ func mergeParams(dst, src Params) error {
 }
 
 func detectPrefix(sql string) byte {
-	indices := paramRegex.FindAllStringIndex(sql, -1)
-	for _, idx := range indices {
-		if idx[0] > 0 && sql[idx[0]-1] == sql[idx[0]] {
-			continue
-		}
-		return sql[idx[0]]
+	if phs := placeholder.Scan(sql); len(phs) > 0 {
+		return phs[0].Prefix
 	}
 	return 0
 }
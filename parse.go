@@ -0,0 +1,361 @@
+package squildx
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/modfin/squildx/internal/placeholder"
+)
+
+// Parse turns a hand-written SELECT into a Builder that can be extended like
+// any other. It understands
+//
+//	SELECT [DISTINCT] ... FROM ... [JOIN ...] [WHERE ...] [GROUP BY ...]
+//	[HAVING ...] [ORDER BY ...] [LIMIT n] [OFFSET n]
+//
+// Top-level AND-ed WHERE and HAVING predicates become separate clauses; a
+// predicate list containing a top-level OR is kept whole, in parentheses, so
+// that later Where calls cannot change its meaning. Each params key is given
+// to the clauses using it. Comments are removed, so that a trailing -- comment
+// cannot swallow clauses added later. Parse fails with ErrParse on anything
+// else, such as WITH, UNION or FOR UPDATE, and with the error Build would
+// return when the params do not match the placeholders.
+func Parse(sql string, params ...Params) (Builder, error) {
+	all, err := extractParams(params)
+	if err != nil {
+		return nil, err
+	}
+	sql, err = stripComments(sql)
+	if err != nil {
+		return nil, err
+	}
+	clauses, err := splitClauses(sql)
+	if err != nil {
+		return nil, err
+	}
+
+	used := make(map[string]bool)
+	paramsFor := func(text string) Params {
+		var p Params
+		for _, ph := range placeholder.Scan(text) {
+			if v, ok := all[ph.Name]; ok {
+				if p == nil {
+					p = make(Params)
+				}
+				p[ph.Name] = v
+				used[ph.Name] = true
+			}
+		}
+		return p
+	}
+	withParams := func(text string) []Params {
+		if p := paramsFor(text); p != nil {
+			return []Params{p}
+		}
+		return nil
+	}
+
+	var b Builder = New()
+	for _, c := range clauses {
+		switch c.keyword {
+		case "SELECT":
+			b = b.Select(splitList(c.text)...)
+			if c.distinct {
+				b = b.Distinct()
+			}
+		case "FROM":
+			if len(placeholder.Scan(c.text)) > 0 {
+				return nil, fmt.Errorf("%w: placeholders in FROM are not supported", ErrParse)
+			}
+			b = b.From(c.text)
+		case "JOIN", "INNER JOIN":
+			b = b.InnerJoin(c.text, withParams(c.text)...)
+		case "LEFT JOIN":
+			b = b.LeftJoin(c.text, withParams(c.text)...)
+		case "RIGHT JOIN":
+			b = b.RightJoin(c.text, withParams(c.text)...)
+		case "FULL JOIN":
+			b = b.FullJoin(c.text, withParams(c.text)...)
+		case "CROSS JOIN":
+			b = b.CrossJoin(c.text, withParams(c.text)...)
+		case "WHERE":
+			for _, pred := range splitPredicates(c.text) {
+				b = b.Where(pred, withParams(pred)...)
+			}
+		case "GROUP BY":
+			b = b.GroupBy(splitList(c.text)...)
+		case "HAVING":
+			for _, pred := range splitPredicates(c.text) {
+				b = b.Having(pred, withParams(pred)...)
+			}
+		case "ORDER BY":
+			for _, expr := range splitList(c.text) {
+				b = b.OrderBy(expr, withParams(expr)...)
+			}
+		case "LIMIT", "OFFSET":
+			n, err := strconv.ParseUint(c.text, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s %q is not a number", ErrParse, c.keyword, c.text)
+			}
+			if c.keyword == "LIMIT" {
+				b = b.Limit(n)
+			} else {
+				b = b.Offset(n)
+			}
+		}
+	}
+
+	for key := range all {
+		if !used[key] {
+			return nil, fmt.Errorf("%w: %q", ErrExtraParam, key)
+		}
+	}
+	if _, _, err := b.Build(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// sqlClause is a top-level clause of a SELECT: its normalized keyword, such as
+// "LEFT JOIN", and the text up to the next clause.
+type sqlClause struct {
+	keyword  string
+	text     string
+	distinct bool // SELECT DISTINCT
+}
+
+// clauseKeywords lists the words starting a clause, with the words that may
+// follow them in a multi-word keyword.
+var clauseKeywords = map[string][]string{
+	"SELECT": nil,
+	"FROM":   nil,
+	"JOIN":   nil,
+	"INNER":  {"JOIN"},
+	"LEFT":   {"OUTER", "JOIN"},
+	"RIGHT":  {"OUTER", "JOIN"},
+	"FULL":   {"OUTER", "JOIN"},
+	"CROSS":  {"JOIN"},
+	"WHERE":  nil,
+	"GROUP":  {"BY"},
+	"HAVING": nil,
+	"ORDER":  {"BY"},
+	"LIMIT":  nil,
+	"OFFSET": nil,
+}
+
+var unsupportedKeywords = []string{"WITH", "UNION", "INTERSECT", "EXCEPT", "NATURAL", "WINDOW", "FETCH", "FOR"}
+
+// clauseOrder is the position each clause must appear in; joins may repeat.
+var clauseOrder = map[string]int{
+	"SELECT": 0, "FROM": 1, "JOIN": 2, "INNER JOIN": 2, "LEFT JOIN": 2, "RIGHT JOIN": 2,
+	"FULL JOIN": 2, "CROSS JOIN": 2, "WHERE": 3, "GROUP BY": 4, "HAVING": 5,
+	"ORDER BY": 6, "LIMIT": 7, "OFFSET": 7,
+}
+
+func splitClauses(sql string) ([]sqlClause, error) {
+	sql = strings.TrimSuffix(strings.TrimSpace(sql), ";")
+	words := topLevelWords(sql)
+	if len(words) == 0 || words[0].upper != "SELECT" && !slices.Contains(unsupportedKeywords, words[0].upper) {
+		return nil, fmt.Errorf("%w: expected SELECT", ErrParse)
+	}
+
+	var clauses []sqlClause
+	textStart := 0
+	closeClause := func(end int) {
+		if len(clauses) > 0 {
+			clauses[len(clauses)-1].text = strings.TrimSpace(sql[textStart:end])
+		}
+	}
+	for i := 0; i < len(words); i++ {
+		w := words[i]
+		if slices.Contains(unsupportedKeywords, w.upper) {
+			return nil, fmt.Errorf("%w: %s is not supported", ErrParse, w.upper)
+		}
+		follow, ok := clauseKeywords[w.upper]
+		if !ok {
+			continue
+		}
+		// IS [NOT] DISTINCT FROM is a comparison, not a FROM clause.
+		if w.upper == "FROM" && i >= 2 && words[i-1].upper == "DISTINCT" &&
+			(words[i-2].upper == "IS" || words[i-2].upper == "NOT") {
+			continue
+		}
+		keyword, end := w.upper, w.end
+		j := i + 1
+		for _, next := range follow {
+			if j < len(words) && words[j].upper == next {
+				if next != "OUTER" {
+					keyword += " " + next
+				}
+				end = words[j].end
+				j++
+			} else if next != "OUTER" {
+				return nil, fmt.Errorf("%w: expected %s after %s", ErrParse, next, w.upper)
+			}
+		}
+		closeClause(w.start)
+		clauses = append(clauses, sqlClause{keyword: keyword})
+		textStart = end
+		i = j - 1
+
+		if keyword == "SELECT" && j < len(words) && words[j].upper == "DISTINCT" && words[j].start == nextWordStart(sql, end) {
+			if j+1 < len(words) && words[j+1].upper == "ON" {
+				return nil, fmt.Errorf("%w: DISTINCT ON is not supported", ErrParse)
+			}
+			clauses[len(clauses)-1].distinct = true
+			textStart = words[j].end
+			i = j
+		}
+	}
+	closeClause(len(sql))
+
+	last := -1
+	seen := make(map[string]bool)
+	for _, c := range clauses {
+		order := clauseOrder[c.keyword]
+		if order < last || (order != 2 && seen[c.keyword]) {
+			return nil, fmt.Errorf("%w: unexpected %s", ErrParse, c.keyword)
+		}
+		if c.text == "" {
+			return nil, fmt.Errorf("%w: empty %s clause", ErrParse, c.keyword)
+		}
+		last = order
+		seen[c.keyword] = true
+	}
+	if !seen["FROM"] {
+		return nil, fmt.Errorf("%w: expected FROM", ErrParse)
+	}
+	return clauses, nil
+}
+
+// stripComments replaces the -- and /* */ comments of sql, outside quotes,
+// with a space. Block comments may nest, as in PostgreSQL.
+func stripComments(sql string) (string, error) {
+	var sb strings.Builder
+	var quote byte
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case strings.HasPrefix(sql[i:], "--"):
+			end := strings.IndexByte(sql[i:], '\n')
+			if end < 0 {
+				end = len(sql) - i
+			}
+			sb.WriteByte(' ')
+			i += end - 1
+			continue
+		case strings.HasPrefix(sql[i:], "/*"):
+			depth := 0
+			j := i
+			for ; j < len(sql); j++ {
+				if strings.HasPrefix(sql[j:], "/*") {
+					depth++
+					j++
+				} else if strings.HasPrefix(sql[j:], "*/") {
+					depth--
+					j++
+					if depth == 0 {
+						break
+					}
+				}
+			}
+			if depth > 0 {
+				return "", fmt.Errorf("%w: unterminated comment", ErrParse)
+			}
+			sb.WriteByte(' ')
+			i = j
+			continue
+		}
+		sb.WriteByte(c)
+	}
+	return sb.String(), nil
+}
+
+// sqlWord is a word outside parentheses and quotes.
+type sqlWord struct {
+	upper      string
+	start, end int
+}
+
+// topLevelWords returns the words of sql outside parentheses and quotes.
+func topLevelWords(sql string) []sqlWord {
+	var words []sqlWord
+	depth := 0
+	var quote byte
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case depth == 0 && isWordByte(c) && (i == 0 || !isWordByte(sql[i-1]) && sql[i-1] != ':' && sql[i-1] != '@' && sql[i-1] != '.'):
+			j := i
+			for j < len(sql) && isWordByte(sql[j]) {
+				j++
+			}
+			words = append(words, sqlWord{upper: strings.ToUpper(sql[i:j]), start: i, end: j})
+			i = j - 1
+		}
+	}
+	return words
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// nextWordStart returns the index of the first non-space byte at or after i.
+func nextWordStart(sql string, i int) int {
+	for i < len(sql) && isSpace(sql[i]) {
+		i++
+	}
+	return i
+}
+
+// splitList splits a comma-separated list such as a select list.
+func splitList(text string) []string {
+	parts := splitTopLevel(text, ',')
+	for i, p := range parts {
+		parts[i] = strings.TrimSpace(p)
+	}
+	return parts
+}
+
+// splitPredicates splits text at its top-level ANDs, except those of
+// BETWEEN ... AND. Text with a top-level OR is returned whole, parenthesized.
+func splitPredicates(text string) []string {
+	words := topLevelWords(text)
+	var preds []string
+	start, between := 0, false
+	for _, w := range words {
+		switch w.upper {
+		case "OR":
+			return []string{"(" + text + ")"}
+		case "BETWEEN":
+			between = true
+		case "AND":
+			if between {
+				between = false
+				continue
+			}
+			preds = append(preds, strings.TrimSpace(text[start:w.start]))
+			start = w.end
+		}
+	}
+	return append(preds, strings.TrimSpace(text[start:]))
+}
@@ -0,0 +1,165 @@
+package squildx
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	b, err := Parse(`SELECT DISTINCT u.id, u.name, count(o.id) AS orders
+		FROM users u
+		LEFT OUTER JOIN orders o ON o.user_id = u.id AND o.status = :status
+		WHERE u.active = :active AND u.age BETWEEN :min AND :max AND u.name <> 'a AND b'
+		GROUP BY u.id, u.name
+		HAVING count(o.id) > :min_orders
+		ORDER BY u.name ASC, u.id
+		LIMIT 10 OFFSET 20;`,
+		Params{"status": "paid", "active": true, "min": 18, "max": 65, "min_orders": 2})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	q, params, err := b.Where("u.country = :country", Params{"country": "SE"}).Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "SELECT DISTINCT u.id, u.name, count(o.id) AS orders FROM users u" +
+		" LEFT JOIN orders o ON o.user_id = u.id AND o.status = :status" +
+		" WHERE u.active = :active AND u.age BETWEEN :min AND :max AND u.name <> 'a AND b' AND u.country = :country" +
+		" GROUP BY u.id, u.name HAVING count(o.id) > :min_orders ORDER BY u.name ASC, u.id LIMIT 10 OFFSET 20"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+	assertParam(t, params, "status", "paid")
+	assertParam(t, params, "min", 18)
+	assertParam(t, params, "country", "SE")
+}
+
+func TestParse_WhereClausesSplit(t *testing.T) {
+	b, err := Parse("SELECT id FROM users WHERE a = 1 AND (b = 2 OR c = 3) AND d IS DISTINCT FROM e")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := b.(*builder).wheres
+	want := []string{"a = 1", "(b = 2 OR c = 3)", "d IS DISTINCT FROM e"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d where clauses, got %d: %v", len(want), len(got), got)
+	}
+	for i := range want {
+		if got[i].sql != want[i] {
+			t.Errorf("where #%d = %q, want %q", i, got[i].sql, want[i])
+		}
+	}
+}
+
+func TestParse_TopLevelOrKeptWhole(t *testing.T) {
+	b, err := Parse("SELECT id FROM users WHERE a = 1 AND b = 2 OR c = 3")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	q, _, err := b.Where("d = 4").Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "SELECT id FROM users WHERE (a = 1 AND b = 2 OR c = 3) AND d = 4"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+}
+
+func TestParse_Joins(t *testing.T) {
+	b, err := Parse("select a.id from a join b on b.a_id = a.id inner join c using (id) right join d on true full outer join e on true cross join f")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	q, _, err := b.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "SELECT a.id FROM a INNER JOIN b on b.a_id = a.id INNER JOIN c using (id) RIGHT JOIN d on true FULL JOIN e on true CROSS JOIN f"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+}
+
+func TestParse_Subqueries(t *testing.T) {
+	b, err := Parse("SELECT id, (SELECT max(x) FROM y WHERE y.id = t.id) AS m FROM t WHERE id IN (SELECT id FROM z WHERE k = :k)", Params{"k": 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	q, _, err := b.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "SELECT id, (SELECT max(x) FROM y WHERE y.id = t.id) AS m FROM t WHERE id IN (SELECT id FROM z WHERE k = :k)"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+}
+
+func TestParse_Comments(t *testing.T) {
+	b, err := Parse("SELECT id -- the key\nFROM users /* FROM here is a comment */\nWHERE active -- only active\nORDER BY name")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	q, _, err := b.Where("z = 1").Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "SELECT id FROM users WHERE active AND z = 1 ORDER BY name"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+
+	b, err = Parse("SELECT '--not a comment' AS s, /* outer /* nested */ still */ id FROM users")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cols := b.Columns(); len(cols) != 2 || cols[0] != "'--not a comment' AS s" || cols[1] != "id" {
+		t.Errorf("unexpected columns: %q", cols)
+	}
+
+	if _, err := Parse("SELECT id FROM users /* unterminated"); !errors.Is(err, ErrParse) {
+		t.Errorf("expected ErrParse for an unterminated comment, got: %v", err)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := map[string]string{
+		"not a select":     "UPDATE users SET a = 1",
+		"with":             "WITH x AS (SELECT 1) SELECT * FROM x",
+		"union":            "SELECT id FROM a UNION SELECT id FROM b",
+		"for update":       "SELECT id FROM a FOR UPDATE",
+		"no from":          "SELECT 1",
+		"order":            "SELECT id FROM a ORDER BY id WHERE id = 1",
+		"two wheres":       "SELECT id FROM a WHERE id = 1 WHERE id = 2",
+		"limit param":      "SELECT id FROM a LIMIT :n",
+		"distinct on":      "SELECT DISTINCT ON (a) a, b FROM t",
+		"group without by": "SELECT id FROM a GROUP id",
+	}
+	for name, sql := range tests {
+		if _, err := Parse(sql); !errors.Is(err, ErrParse) {
+			t.Errorf("%s: expected ErrParse, got: %v", name, err)
+		}
+	}
+}
+
+func TestParse_ParamErrors(t *testing.T) {
+	if _, err := Parse("SELECT id FROM a WHERE id = :id"); !errors.Is(err, ErrMissingParam) {
+		t.Errorf("expected ErrMissingParam, got: %v", err)
+	}
+	if _, err := Parse("SELECT id FROM a", Params{"id": 1}); !errors.Is(err, ErrExtraParam) {
+		t.Errorf("expected ErrExtraParam, got: %v", err)
+	}
+}
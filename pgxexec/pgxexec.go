@@ -0,0 +1,100 @@
+// Package pgxexec runs squildx queries with pgx.
+//
+// pgx binds named arguments written with the @ prefix (pgx.NamedArgs) and
+// positional arguments written as $1, $2 and so on. Named and Positional
+// rewrite the placeholders of a built query to either form, whichever prefix
+// the query was written with, so the same builders work with sqlx and pgx:
+//
+//	sql, args, err := pgxexec.Named(q)
+//	rows, err := conn.Query(ctx, sql, args)
+package pgxexec
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/modfin/squildx"
+	"github.com/modfin/squildx/internal/placeholder"
+)
+
+// Query is implemented by every squildx builder.
+type Query interface {
+	Build() (string, squildx.Params, error)
+}
+
+// Named builds q and rewrites its placeholders to @name, returning the params
+// as pgx.NamedArgs.
+func Named(q Query) (string, pgx.NamedArgs, error) {
+	sql, params, err := q.Build()
+	if err != nil {
+		return "", nil, err
+	}
+	sql = placeholder.Rewrite(sql, func(ph placeholder.Placeholder) string {
+		return "@" + ph.Name
+	})
+	return sql, pgx.NamedArgs(params), nil
+}
+
+// Positional builds q and rewrites its placeholders to $1, $2, ... in order of
+// first appearance, returning the matching arguments. A placeholder used more
+// than once keeps its number.
+func Positional(q Query) (string, []any, error) {
+	sql, params, err := q.Build()
+	if err != nil {
+		return "", nil, err
+	}
+	var args []any
+	positions := make(map[string]int)
+	sql = placeholder.Rewrite(sql, func(ph placeholder.Placeholder) string {
+		n, ok := positions[ph.Name]
+		if !ok {
+			args = append(args, params[ph.Name])
+			n = len(args)
+			positions[ph.Name] = n
+		}
+		return "$" + strconv.Itoa(n)
+	})
+	return sql, args, nil
+}
+
+// Queue builds each query and queues it on batch, so that they are sent to
+// the server in a single round trip. Nothing is queued if any query fails to
+// build.
+func Queue(batch *pgx.Batch, queries ...Query) error {
+	type queued struct {
+		sql  string
+		args pgx.NamedArgs
+	}
+	built := make([]queued, len(queries))
+	for i, q := range queries {
+		sql, args, err := Named(q)
+		if err != nil {
+			return fmt.Errorf("pgxexec: query #%d: %w", i, err)
+		}
+		built[i] = queued{sql: sql, args: args}
+	}
+	for _, q := range built {
+		batch.Queue(q.sql, q.args)
+	}
+	return nil
+}
+
+// CopyFromer is implemented by *pgx.Conn and pgx.Tx.
+type CopyFromer interface {
+	CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error)
+}
+
+// CopyFrom loads the rows of ins with the COPY protocol instead of an INSERT.
+// It fails with squildx.ErrNotCopyable when ins has an ON CONFLICT or
+// RETURNING clause, a SELECT, or values other than placeholders.
+func CopyFrom(ctx context.Context, conn CopyFromer, ins squildx.InsertBuilder) (int64, error) {
+	table, columns, rows, err := ins.CopyRows()
+	if err != nil {
+		return 0, err
+	}
+	return conn.CopyFrom(ctx, pgx.Identifier(strings.Split(table, ".")), columns, pgx.CopyFromRows(rows))
+}
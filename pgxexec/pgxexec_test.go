@@ -0,0 +1,171 @@
+package pgxexec
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/modfin/squildx"
+)
+
+func TestNamed(t *testing.T) {
+	q := squildx.New().
+		Select("id").
+		From("users").
+		Where("name = :name OR nickname = :name", squildx.Params{"name": "Al"}).
+		Where("created_at > :since::timestamptz", squildx.Params{"since": "2024-01-01"})
+
+	sql, args, err := Named(q)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "SELECT id FROM users WHERE name = @name OR nickname = @name AND created_at > @since::timestamptz"
+	if sql != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", sql, expected)
+	}
+	want := pgx.NamedArgs{"name": "Al", "since": "2024-01-01"}
+	if !reflect.DeepEqual(args, want) {
+		t.Errorf("args = %v, want %v", args, want)
+	}
+}
+
+func TestNamed_AtPrefixUnchanged(t *testing.T) {
+	q := squildx.New().Select("id").From("users").Where("id = @id", squildx.Params{"id": 1})
+
+	sql, _, err := Named(q)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sql != "SELECT id FROM users WHERE id = @id" {
+		t.Errorf("unexpected SQL: %s", sql)
+	}
+}
+
+func TestPositional(t *testing.T) {
+	q := squildx.NewUpdate().
+		Table("users").
+		Set("name = :name, nickname = :name", squildx.Params{"name": "Al"}).
+		Where("id = :id", squildx.Params{"id": 7})
+
+	sql, args, err := Positional(q)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "UPDATE users SET name = $1, nickname = $1 WHERE id = $2"
+	if sql != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", sql, expected)
+	}
+	if !reflect.DeepEqual(args, []any{"Al", 7}) {
+		t.Errorf("args = %v", args)
+	}
+}
+
+func TestNamed_BuildError(t *testing.T) {
+	if _, _, err := Named(squildx.New().Select("id")); !errors.Is(err, squildx.ErrNoFrom) {
+		t.Errorf("expected ErrNoFrom, got: %v", err)
+	}
+	if _, _, err := Positional(squildx.New().Select("id")); !errors.Is(err, squildx.ErrNoFrom) {
+		t.Errorf("expected ErrNoFrom, got: %v", err)
+	}
+}
+
+func TestQueue(t *testing.T) {
+	batch := &pgx.Batch{}
+	err := Queue(batch,
+		squildx.NewDelete().From("sessions").Where("user_id = :id", squildx.Params{"id": 1}),
+		squildx.NewUpdate().Table("users").Set("active = :active", squildx.Params{"active": false}).Where("id = :id", squildx.Params{"id": 1}),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if batch.Len() != 2 {
+		t.Fatalf("expected 2 queued queries, got %d", batch.Len())
+	}
+	first := batch.QueuedQueries[0]
+	if first.SQL != "DELETE FROM sessions WHERE user_id = @id" {
+		t.Errorf("unexpected SQL: %s", first.SQL)
+	}
+	if !reflect.DeepEqual(first.Arguments, []any{pgx.NamedArgs{"id": 1}}) {
+		t.Errorf("unexpected arguments: %v", first.Arguments)
+	}
+}
+
+func TestQueue_BuildError(t *testing.T) {
+	batch := &pgx.Batch{}
+	err := Queue(batch,
+		squildx.NewDelete().From("sessions").All(),
+		squildx.NewDelete().From("users"),
+	)
+	if !errors.Is(err, squildx.ErrDeleteNoWhere) {
+		t.Errorf("expected ErrDeleteNoWhere, got: %v", err)
+	}
+	if batch.Len() != 0 {
+		t.Errorf("expected nothing queued, got %d", batch.Len())
+	}
+}
+
+type fakeCopier struct {
+	table   pgx.Identifier
+	columns []string
+	rows    [][]any
+}
+
+func (c *fakeCopier) CopyFrom(_ context.Context, table pgx.Identifier, columns []string, src pgx.CopyFromSource) (int64, error) {
+	c.table, c.columns = table, columns
+	for src.Next() {
+		values, err := src.Values()
+		if err != nil {
+			return 0, err
+		}
+		c.rows = append(c.rows, values)
+	}
+	return int64(len(c.rows)), src.Err()
+}
+
+func TestCopyFrom(t *testing.T) {
+	ins := squildx.NewInsert().
+		Into("public.users").
+		Columns("id", "name").
+		Values(":id, :name", squildx.Params{"id": 1, "name": "Alice"}).
+		Values(":id2, :name2", squildx.Params{"id2": 2, "name2": "Bob"})
+
+	c := &fakeCopier{}
+	n, err := CopyFrom(context.Background(), c, ins)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("copied %d rows, want 2", n)
+	}
+	if !reflect.DeepEqual(c.table, pgx.Identifier{"public", "users"}) {
+		t.Errorf("table = %v", c.table)
+	}
+	if !reflect.DeepEqual(c.columns, []string{"id", "name"}) {
+		t.Errorf("columns = %v", c.columns)
+	}
+	if !reflect.DeepEqual(c.rows, [][]any{{1, "Alice"}, {2, "Bob"}}) {
+		t.Errorf("rows = %v", c.rows)
+	}
+}
+
+func TestCopyFrom_NotCopyable(t *testing.T) {
+	ins := squildx.NewInsert().
+		Into("users").
+		Columns("id").
+		Values(":id", squildx.Params{"id": 1}).
+		Returning("id")
+
+	c := &fakeCopier{}
+	if _, err := CopyFrom(context.Background(), c, ins); !errors.Is(err, squildx.ErrNotCopyable) {
+		t.Errorf("expected ErrNotCopyable, got: %v", err)
+	}
+	if c.table != nil {
+		t.Errorf("CopyFrom should not be called")
+	}
+}
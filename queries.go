@@ -0,0 +1,176 @@
+package squildx
+
+import (
+	"bufio"
+	"fmt"
+	"io/fs"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/modfin/squildx/internal/placeholder"
+)
+
+// NamedQuery is an SQL statement loaded from a file by LoadQueries.
+type NamedQuery struct {
+	Name string
+	File string
+	SQL  string
+}
+
+// QuerySet holds the queries loaded by LoadQueries, by name.
+type QuerySet struct {
+	queries map[string]NamedQuery
+}
+
+var nameMarker = regexp.MustCompile(`^--\s*name:\s*(\S+)\s*$`)
+
+// LoadQueries reads the queries of the files in fsys matching the glob
+// patterns, "*.sql" when none are given. Each query starts with a marker line
+// naming it and ends at the next marker or the end of the file:
+//
+//	-- name: ListUsers
+//	SELECT id, name FROM users WHERE active = :active
+//
+// A trailing semicolon is dropped. The placeholders of each query are checked
+// with the same scanning rules as Build, and loading fails with ErrQueryFile
+// on mixed prefixes, empty or duplicate queries, or SQL before the first
+// marker. Use it with embed.FS to keep large queries in .sql files.
+func LoadQueries(fsys fs.FS, patterns ...string) (*QuerySet, error) {
+	if len(patterns) == 0 {
+		patterns = []string{"*.sql"}
+	}
+	set := &QuerySet{queries: make(map[string]NamedQuery)}
+	for _, pattern := range patterns {
+		files, err := fs.Glob(fsys, pattern)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			if err := set.load(fsys, file); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return set, nil
+}
+
+func (s *QuerySet) load(fsys fs.FS, file string) error {
+	data, err := fs.ReadFile(fsys, file)
+	if err != nil {
+		return err
+	}
+
+	var current *NamedQuery
+	var body strings.Builder
+	var start int
+	flush := func() error {
+		if current == nil {
+			return nil
+		}
+		current.SQL = strings.TrimSuffix(strings.TrimSpace(body.String()), ";")
+		if err := s.add(*current); err != nil {
+			return fmt.Errorf("%w: %s:%d: %w", ErrQueryFile, file, start, err)
+		}
+		body.Reset()
+		return nil
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if m := nameMarker.FindStringSubmatch(strings.TrimSpace(text)); m != nil {
+			if err := flush(); err != nil {
+				return err
+			}
+			current, start = &NamedQuery{Name: m[1], File: file}, line
+			continue
+		}
+		if current == nil {
+			if trimmed := strings.TrimSpace(text); trimmed != "" && !strings.HasPrefix(trimmed, "--") {
+				return fmt.Errorf("%w: %s:%d: SQL before the first -- name: marker", ErrQueryFile, file, line)
+			}
+			continue
+		}
+		body.WriteString(text)
+		body.WriteByte('\n')
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return flush()
+}
+
+func (s *QuerySet) add(q NamedQuery) error {
+	if q.SQL == "" {
+		return fmt.Errorf("query %s is empty", q.Name)
+	}
+	if prev, ok := s.queries[q.Name]; ok {
+		return fmt.Errorf("query %s is already defined in %s", q.Name, prev.File)
+	}
+	var prefix byte
+	for _, ph := range placeholder.Scan(q.SQL) {
+		if prefix == 0 {
+			prefix = ph.Prefix
+		}
+		if ph.Prefix != prefix {
+			return fmt.Errorf("query %s: %w", q.Name, ErrMixedPrefix)
+		}
+	}
+	s.queries[q.Name] = q
+	return nil
+}
+
+// Get returns the query with the given name.
+func (s *QuerySet) Get(name string) (NamedQuery, bool) {
+	q, ok := s.queries[name]
+	return q, ok
+}
+
+// Names returns the names of the loaded queries in sorted order.
+func (s *QuerySet) Names() []string {
+	return slices.Sorted(maps.Keys(s.queries))
+}
+
+// Fragment returns the query SQL with its params validated as Where does:
+// every placeholder needs a value and every value a placeholder.
+func (q NamedQuery) Fragment(params ...Params) (string, Params, error) {
+	p, err := extractParams(params)
+	if err != nil {
+		return "", nil, err
+	}
+	parsed, _, err := parseParams(q.SQL, p)
+	if err != nil {
+		return "", nil, err
+	}
+	return q.SQL, parsed, nil
+}
+
+// Builder returns a query selecting every column of the named query, aliased
+// by its snake_case name, so it can be extended like any other:
+//
+//	q.Builder(params).Where("list_users.age > :age", ...)
+//	// SELECT * FROM (<ListUsers SQL>) AS list_users WHERE list_users.age > :age
+//
+// Invalid params are reported by Build. Scopes cannot add their conditions to
+// the tables inside the query, so Build fails with ErrScopedNamedQuery while
+// any scope, including a default or inherited one, is active; opt out of
+// them with Unscoped.
+func (q NamedQuery) Builder(params ...Params) Builder {
+	b := New().Select("*").(*builder)
+	b.namedQuery = q.Name
+	sql := q.SQL
+	if lastLine := sql[strings.LastIndexByte(sql, '\n')+1:]; strings.Contains(lastLine, "--") {
+		sql += "\n" // keep a trailing comment from swallowing the closing parenthesis
+	}
+	b.from = "(" + sql + ") AS " + toSnakeCase(q.Name)
+	b.paramPrefix = detectPrefix(q.SQL)
+	_, parsed, err := q.Fragment(params...)
+	if err != nil {
+		b.fail("NamedQuery", 0, q.SQL, fmt.Errorf("%s: %w", q.Name, err))
+		return b
+	}
+	b.fromParams = parsed
+	return b
+}
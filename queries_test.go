@@ -0,0 +1,205 @@
+package squildx
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+var testQueryFS = fstest.MapFS{
+	"users.sql": {Data: []byte(`-- Queries over users.
+
+-- name: ListUsers
+-- Active users of a tenant.
+SELECT id, name, age
+FROM users
+WHERE tenant_id = :tenant_id;
+
+-- name: CountUsers
+SELECT count(*) FROM users
+`)},
+	"reports/revenue.sql": {Data: []byte(`-- name: MonthlyRevenue
+SELECT date_trunc('month', paid_at)::date AS month, sum(amount) AS revenue
+FROM payments
+GROUP BY 1
+`)},
+}
+
+func TestLoadQueries(t *testing.T) {
+	set, err := LoadQueries(testQueryFS, "*.sql", "reports/*.sql")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, want := set.Names(), []string{"CountUsers", "ListUsers", "MonthlyRevenue"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Names() = %v, want %v", got, want)
+	}
+
+	q, ok := set.Get("ListUsers")
+	if !ok {
+		t.Fatal("ListUsers not found")
+	}
+	expected := "-- Active users of a tenant.\nSELECT id, name, age\nFROM users\nWHERE tenant_id = :tenant_id"
+	if q.SQL != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q.SQL, expected)
+	}
+	if q.File != "users.sql" {
+		t.Errorf("File = %q, want users.sql", q.File)
+	}
+
+	if _, ok := set.Get("Missing"); ok {
+		t.Error("expected Missing not to be found")
+	}
+}
+
+func TestLoadQueries_DefaultPattern(t *testing.T) {
+	set, err := LoadQueries(testQueryFS)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := set.Names(), []string{"CountUsers", "ListUsers"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Names() = %v, want %v", got, want)
+	}
+}
+
+func TestLoadQueries_Errors(t *testing.T) {
+	tests := map[string]string{
+		"before marker": "SELECT 1\n-- name: One\nSELECT 1\n",
+		"empty":         "-- name: Empty\n\n-- name: One\nSELECT 1\n",
+		"duplicate":     "-- name: One\nSELECT 1\n-- name: One\nSELECT 2\n",
+		"mixed prefix":  "-- name: Mixed\nSELECT * FROM t WHERE a = :a AND b = @b\n",
+	}
+	for name, data := range tests {
+		_, err := LoadQueries(fstest.MapFS{"q.sql": {Data: []byte(data)}})
+		if !errors.Is(err, ErrQueryFile) {
+			t.Errorf("%s: expected ErrQueryFile, got: %v", name, err)
+		}
+	}
+}
+
+func TestNamedQuery_Builder(t *testing.T) {
+	set, err := LoadQueries(testQueryFS)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	q, _ := set.Get("ListUsers")
+
+	sql, params, err := q.Builder(Params{"tenant_id": 7}).
+		Where("list_users.age > :age", Params{"age": 18}).
+		OrderBy("list_users.name").
+		Limit(10).
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "SELECT * FROM (" + q.SQL + ") AS list_users WHERE list_users.age > :age ORDER BY list_users.name LIMIT 10"
+	if sql != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", sql, expected)
+	}
+	assertParam(t, params, "tenant_id", 7)
+	assertParam(t, params, "age", 18)
+}
+
+func TestNamedQuery_BuilderScoped(t *testing.T) {
+	q := NamedQuery{Name: "ListUsers", SQL: "SELECT * FROM users WHERE active"}
+
+	_, _, err := q.Builder().Scoped(tenantScope(7)).Build()
+	var be *BuildError
+	if !errors.As(err, &be) || be.Method != "NamedQuery" {
+		t.Errorf("expected NamedQuery BuildError, got: %v", err)
+	}
+	if !errors.Is(err, ErrScopedNamedQuery) {
+		t.Errorf("expected ErrScopedNamedQuery, got: %v", err)
+	}
+
+	_, _, err = New().Select("*").From("orders o").
+		WhereIn("o.user_id", q.Builder().Select("id")).
+		Scoped(tenantScope(7)).
+		Build()
+	if !errors.Is(err, ErrScopedNamedQuery) {
+		t.Errorf("expected ErrScopedNamedQuery for an inherited scope, got: %v", err)
+	}
+
+	sql, _, err := q.Builder().Scoped(tenantScope(7)).Unscoped("tenant").Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := "SELECT * FROM (" + q.SQL + ") AS list_users"; sql != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", sql, expected)
+	}
+
+	_, _, err = q.Builder().From("users").Scoped(tenantScope(7)).Build()
+	if err != nil {
+		t.Errorf("From should replace the named query, got: %v", err)
+	}
+}
+
+func TestNamedQuery_BuilderMissingParam(t *testing.T) {
+	q := NamedQuery{Name: "ListUsers", SQL: "SELECT * FROM users WHERE tenant_id = :tenant_id"}
+
+	_, _, err := q.Builder().Build()
+
+	if !errors.Is(err, ErrMissingParam) {
+		t.Errorf("expected ErrMissingParam, got: %v", err)
+	}
+	var be *BuildError
+	if !errors.As(err, &be) || be.Method != "NamedQuery" {
+		t.Errorf("expected NamedQuery BuildError, got: %v", err)
+	}
+}
+
+func TestNamedQuery_BuilderPrefix(t *testing.T) {
+	q := NamedQuery{Name: "ListUsers", SQL: "SELECT * FROM users WHERE tenant_id = @tenant_id"}
+
+	_, _, err := q.Builder(Params{"tenant_id": 7}).Where("age > :age", Params{"age": 18}).Build()
+
+	if !errors.Is(err, ErrMixedPrefix) {
+		t.Errorf("expected ErrMixedPrefix, got: %v", err)
+	}
+}
+
+func TestNamedQuery_Fragment(t *testing.T) {
+	q := NamedQuery{Name: "Active", SQL: "SELECT id FROM users WHERE active = :active"}
+
+	sql, params, err := q.Fragment(Params{"active": true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sql != q.SQL {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", sql, q.SQL)
+	}
+	assertParam(t, params, "active", true)
+
+	if _, _, err := q.Fragment(Params{"active": true, "extra": 1}); !errors.Is(err, ErrExtraParam) {
+		t.Errorf("expected ErrExtraParam, got: %v", err)
+	}
+}
+
+func TestFromResetsDerivedTableParams(t *testing.T) {
+	q := NamedQuery{Name: "Active", SQL: "SELECT id FROM users WHERE active = :active"}
+
+	_, params, err := q.Builder(Params{"active": true}).From("users").Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(params) != 0 {
+		t.Errorf("expected no params, got %v", params)
+	}
+}
+
+func TestNamedQuery_BuilderTrailingComment(t *testing.T) {
+	q := NamedQuery{Name: "Everyone", SQL: "SELECT id FROM users -- every user"}
+
+	sql, _, err := q.Builder().Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "SELECT * FROM (SELECT id FROM users -- every user\n) AS everyone"
+	if sql != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", sql, expected)
+	}
+}
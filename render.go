@@ -0,0 +1,107 @@
+package squildx
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// renderer accumulates params, the detected placeholder prefix and every error
+// encountered while a builder renders its SQL, so Build can report all failures
+// at once instead of stopping at the first.
+type renderer struct {
+	params Params
+	prefix byte
+	errs   []error
+	scopes []Scope // active scopes, inherited by subqueries
+
+	renamed map[string]string // tables renamed by RewriteTables, see originalTable
+}
+
+func newRenderer(prefix byte, errs []error) *renderer {
+	return &renderer{
+		params: make(Params),
+		prefix: prefix,
+		errs:   copySlice(errs),
+	}
+}
+
+func (r *renderer) fail(err error) {
+	r.errs = append(r.errs, err)
+}
+
+func (r *renderer) failClause(method string, index int, sql string, err error) {
+	r.fail(&BuildError{Method: method, Index: index, SQL: sql, Err: err})
+}
+
+// merge adds the clause params to the accumulated params, attributing
+// conflicts to the clause.
+func (r *renderer) merge(method string, index int, sql string, params Params) {
+	if err := mergeParams(r.params, params); err != nil {
+		r.failClause(method, index, sql, err)
+	}
+}
+
+// reconcile checks that the placeholders in sql use the same prefix as the
+// rest of the query.
+func (r *renderer) reconcile(method string, index int, sql string) {
+	prefix, err := reconcilePrefix(r.prefix, detectPrefix(sql))
+	if err != nil {
+		r.failClause(method, index, sql, err)
+		return
+	}
+	r.prefix = prefix
+}
+
+// originalTable returns the name table had before RewriteTables renamed it,
+// which is the name scopes match.
+func (r *renderer) originalTable(table string) string {
+	if orig, ok := r.renamed[table]; ok {
+		return orig
+	}
+	return table
+}
+
+// bindPrefix returns the prefix for a placeholder generated at build time:
+// that of the query, or : when it has no placeholders yet.
+func (r *renderer) bindPrefix() byte {
+	if r.prefix == 0 {
+		r.prefix = ':'
+	}
+	return r.prefix
+}
+
+// subquery builds sub with the active scopes and merges its params. The
+// returned bool reports whether the subquery built successfully.
+func (r *renderer) subquery(method string, index int, sub Builder) (string, bool) {
+	subSQL, subParams, err := buildNested(sub, r)
+	if err != nil {
+		r.failClause(method, index, "", err)
+		return "", false
+	}
+	r.reconcile(method, index, subSQL)
+	r.merge(method, index, subSQL, subParams)
+	return subSQL, true
+}
+
+// wheres renders AND-ed WHERE clauses, including EXISTS/IN subqueries.
+func (r *renderer) wheres(wheres []paramClause) string {
+	ands := make([]string, len(wheres))
+	for i, w := range wheres {
+		if w.subQuery != nil {
+			subSQL, _ := r.subquery(w.method, w.index, w.subQuery)
+			ands[i] = fmt.Sprintf("%s (%s)", w.subPrefix, subSQL)
+			continue
+		}
+		ands[i] = w.sql
+		r.merge(w.method, w.index, w.sql, w.params)
+	}
+	return strings.Join(ands, " AND ")
+}
+
+func (r *renderer) result(sql string) (string, Params, error) {
+	if len(r.errs) > 0 {
+		return "", nil, errors.Join(r.errs...)
+	}
+	return sql, r.params, nil
+}
@@ -0,0 +1,398 @@
+package squildx
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync/atomic"
+)
+
+// Scope is a named condition that Build adds for every table of a query it
+// applies to, such as a tenant filter or a soft-delete filter. Scopes reach the
+// FROM table, joined tables and the tables of WHERE and lateral subqueries.
+type Scope struct {
+	name   string
+	tables []string
+	sql    string
+	params Params
+	prefix byte
+	err    error
+}
+
+// NewScope returns a scope applying sql to every table. {table} in sql is
+// replaced by the alias of the table the condition is added for:
+//
+//	squildx.NewScope("tenant", "{table}.tenant_id = :tenant_id", squildx.Params{"tenant_id": id})
+//
+// Invalid placeholders are reported by Build of any query the scope applies to.
+func NewScope(name, sql string, params ...Params) Scope {
+	s := Scope{name: name, sql: sql}
+	p, err := extractParams(params)
+	if err != nil {
+		s.err = err
+		return s
+	}
+	s.params, s.prefix, s.err = parseParams(sql, p)
+	return s
+}
+
+// ForTables restricts the scope to the named tables. A schema-qualified table
+// in a query, such as public.users, matches either its full or its bare name.
+// Names match regardless of case and identifier quotes.
+func (s Scope) ForTables(tables ...string) Scope {
+	s.tables = append(copySlice(s.tables), tables...)
+	return s
+}
+
+// Name returns the name used to opt out of the scope with Unscoped.
+func (s Scope) Name() string {
+	return s.name
+}
+
+func (s Scope) appliesTo(table string) bool {
+	if len(s.tables) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(s.tables, func(t string) bool {
+		return tableMatches(table, t)
+	})
+}
+
+// tableMatches reports whether table, as written in a query, is the table
+// name: the same table or, when table is schema-qualified, one with the same
+// bare name. Case and identifier quotes are ignored.
+func tableMatches(table, name string) bool {
+	table, name = normalizeTable(table), normalizeTable(name)
+	return table == name || bareTableName(table) == name
+}
+
+func normalizeTable(table string) string {
+	return strings.ToLower(strings.ReplaceAll(table, `"`, ""))
+}
+
+type scopesHolder struct{ scopes []Scope }
+
+var defaultScopes atomic.Pointer[scopesHolder]
+
+// SetDefaultScopes sets the scopes applied to every SELECT, UPDATE and DELETE
+// unless opted out of with Unscoped. It replaces any previous defaults.
+func SetDefaultScopes(scopes ...Scope) {
+	defaultScopes.Store(&scopesHolder{scopes: copySlice(scopes)})
+}
+
+// scoping holds the scopes added to and removed from a single builder.
+type scoping struct {
+	scopes      []Scope
+	unscoped    []string
+	unscopedAll bool
+}
+
+func (s scoping) clone() scoping {
+	s.scopes = copySlice(s.scopes)
+	s.unscoped = copySlice(s.unscoped)
+	return s
+}
+
+// active returns the scopes that apply to a query. A top-level query starts
+// from the default scopes and a subquery from those of its parent query.
+// A scope added with Scoped replaces an inherited scope of the same name.
+func (s scoping) active(parent *renderer) []Scope {
+	if s.unscopedAll {
+		return nil
+	}
+	var base []Scope
+	if parent != nil {
+		base = parent.scopes
+	} else if h := defaultScopes.Load(); h != nil {
+		base = h.scopes
+	}
+	removed := func(sc Scope) bool {
+		return slices.Contains(s.unscoped, sc.name)
+	}
+	var out []Scope
+	for _, sc := range base {
+		own := slices.ContainsFunc(s.scopes, func(o Scope) bool { return o.name == sc.name })
+		if !own && !removed(sc) {
+			out = append(out, sc)
+		}
+	}
+	for _, sc := range s.scopes {
+		if !removed(sc) {
+			out = append(out, sc)
+		}
+	}
+	return out
+}
+
+func (s *scoping) add(scopes []Scope) {
+	s.scopes = append(s.scopes, scopes...)
+}
+
+func (s *scoping) remove(names []string) {
+	if len(names) == 0 {
+		s.unscopedAll = true
+		return
+	}
+	s.unscoped = append(s.unscoped, names...)
+}
+
+// Scoped adds scopes to the query and every subquery in it.
+func (b *builder) Scoped(scopes ...Scope) Builder {
+	cp := b.clone()
+	cp.scoping.add(scopes)
+	return cp
+}
+
+// Unscoped opts the query and its subqueries out of the named scopes, or out
+// of every scope when called without names.
+func (b *builder) Unscoped(names ...string) Builder {
+	cp := b.clone()
+	cp.scoping.remove(names)
+	return cp
+}
+
+func (b *updateBuilder) Scoped(scopes ...Scope) UpdateBuilder {
+	cp := b.clone()
+	cp.scoping.add(scopes)
+	return cp
+}
+
+func (b *updateBuilder) Unscoped(names ...string) UpdateBuilder {
+	cp := b.clone()
+	cp.scoping.remove(names)
+	return cp
+}
+
+func (b *deleteBuilder) Scoped(scopes ...Scope) DeleteBuilder {
+	cp := b.clone()
+	cp.scoping.add(scopes)
+	return cp
+}
+
+func (b *deleteBuilder) Unscoped(names ...string) DeleteBuilder {
+	cp := b.clone()
+	cp.scoping.remove(names)
+	return cp
+}
+
+// scopeConditions returns the conditions of the active scopes for the table
+// references in refs, a FROM list such as "users u, orders AS o", added by
+// method. A reference whose tables scopes cannot see, such as a derived table
+// or a table function, fails with ErrScopedTableRef.
+func (r *renderer) scopeConditions(method string, index int, refs string) []string {
+	if len(r.scopes) == 0 {
+		return nil
+	}
+	var conds []string
+	for _, ref := range splitTopLevel(refs, ',') {
+		if strings.TrimSpace(ref) == "" {
+			continue
+		}
+		table, alias, ok := parseTableRef(ref)
+		if !ok {
+			r.failClause(method, index, ref, fmt.Errorf("%w: scoped by %s", ErrScopedTableRef, r.scopeNames()))
+			continue
+		}
+		for i := range r.scopes {
+			s := &r.scopes[i]
+			if !s.appliesTo(r.originalTable(table)) {
+				continue
+			}
+			if s.err != nil {
+				r.failClause("Scoped", i, s.sql, s.err)
+				s.err = nil // report once, not for every table and subquery
+				continue
+			}
+			cond := strings.ReplaceAll(s.sql, "{table}", alias)
+			r.reconcile("Scoped", i, cond)
+			r.merge("Scoped", i, cond, s.params)
+			conds = append(conds, parenthesizeOr(cond))
+		}
+	}
+	return conds
+}
+
+// scopedWhere appends the scope conditions to the rendered WHERE clauses,
+// parenthesizing the clauses when they contain a top-level OR so the
+// conditions cannot be bypassed.
+func scopedWhere(where string, conds []string) string {
+	if len(conds) == 0 {
+		return where
+	}
+	if where == "" {
+		return strings.Join(conds, " AND ")
+	}
+	return parenthesizeOr(where) + " AND " + strings.Join(conds, " AND ")
+}
+
+// scopedJoin adds the scope conditions for the table of a join clause such as
+// "orders o ON o.user_id = u.id" to its ON condition. Conditions for joins
+// without an ON condition (CROSS JOIN, USING) are returned for the WHERE
+// clause, as are those of RIGHT and FULL joins, whose ON condition does not
+// filter the rows of the joined table.
+func (r *renderer) scopedJoin(jt joinType, index int, sql string) (string, []string) {
+	ref, on, hasOn := splitJoin(sql)
+	conds := r.scopeConditions(jt.method(), index, ref)
+	if len(conds) == 0 || !hasOn || jt == rightJoin || jt == fullJoin {
+		return sql, conds
+	}
+	return ref + " ON " + parenthesizeOr(on) + " AND " + strings.Join(conds, " AND "), nil
+}
+
+var orKeyword = regexp.MustCompile(`(?i)\bOR\b`)
+
+// parenthesizeOr wraps sql in parentheses when it may contain an OR, so that
+// AND-ing another condition to it cannot change its meaning.
+func parenthesizeOr(sql string) string {
+	if orKeyword.MatchString(sql) {
+		return "(" + sql + ")"
+	}
+	return sql
+}
+
+// parseTableRef splits a table reference such as "users", "ONLY users u" or
+// "public.users AS u" into the table and the name it is referred to by.
+// Derived tables such as "(SELECT ...) x" or "LATERAL (...) x" and table
+// functions are reported as not ok.
+func parseTableRef(ref string) (table, alias string, ok bool) {
+	fields, ok := tableRefFields(ref)
+	if !ok {
+		return "", "", false
+	}
+	table, alias = fields[0], fields[0]
+	switch {
+	case len(fields) >= 3 && strings.EqualFold(fields[1], "AS"):
+		alias = fields[2]
+	case len(fields) >= 2:
+		alias = fields[1]
+	}
+	return table, alias, true
+}
+
+// tableRefFields splits a table reference into its words, keeping quoted
+// identifiers whole and dropping a leading ONLY. ok is false for references
+// that do not name a table.
+func tableRefFields(ref string) ([]string, bool) {
+	if strings.Contains(ref, "(") {
+		return nil, false
+	}
+	var fields []string
+	start := -1
+	var quoted bool
+	for i := 0; i <= len(ref); i++ {
+		if i < len(ref) && ref[i] == '"' {
+			quoted = !quoted
+		}
+		if i == len(ref) || (!quoted && isSpace(ref[i])) {
+			if start >= 0 {
+				fields = append(fields, ref[start:i])
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if len(fields) > 0 && strings.EqualFold(fields[0], "ONLY") {
+		fields = fields[1:]
+	}
+	if len(fields) == 0 || strings.EqualFold(fields[0], "LATERAL") {
+		return nil, false
+	}
+	return fields, true
+}
+
+// scopeNames lists the names of the active scopes for error messages.
+func (r *renderer) scopeNames() string {
+	names := make([]string, len(r.scopes))
+	for i, s := range r.scopes {
+		names[i] = s.name
+	}
+	return strings.Join(names, ", ")
+}
+
+// splitJoin splits a join clause at its top-level ON or USING keyword. on is
+// only set for ON; for USING the returned ref still excludes the USING part.
+func splitJoin(sql string) (ref, on string, hasOn bool) {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case depth == 0 && (i == 0 || isSpace(sql[i-1])):
+			if keywordAt(sql, i, "ON") {
+				return strings.TrimSpace(sql[:i]), strings.TrimSpace(sql[i+2:]), true
+			}
+			if keywordAt(sql, i, "USING") {
+				return strings.TrimSpace(sql[:i]), "", false
+			}
+		}
+	}
+	return sql, "", false
+}
+
+// splitTopLevel splits sql at each sep outside parentheses and quotes.
+func splitTopLevel(sql string, sep byte) []string {
+	var parts []string
+	depth, start := 0, 0
+	var quote byte
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == sep && depth == 0:
+			parts = append(parts, sql[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, sql[start:])
+}
+
+// keywordAt reports whether the keyword kw starts at sql[i] and is followed
+// by whitespace, a parenthesis or the end of sql.
+func keywordAt(sql string, i int, kw string) bool {
+	end := i + len(kw)
+	if end > len(sql) || !strings.EqualFold(sql[i:end], kw) {
+		return false
+	}
+	return end == len(sql) || isSpace(sql[end]) || sql[end] == '('
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+// scopedBuilder is implemented by builders whose scopes propagate from the
+// query they are nested in.
+type scopedBuilder interface {
+	build(parent *renderer) (string, Params, error)
+}
+
+// buildNested builds a subquery of the query rendered by parent.
+func buildNested(sub Builder, parent *renderer) (string, Params, error) {
+	if s, ok := sub.(scopedBuilder); ok {
+		return s.build(parent)
+	}
+	return sub.Build()
+}
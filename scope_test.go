@@ -0,0 +1,469 @@
+package squildx
+
+import (
+	"errors"
+	"testing"
+)
+
+func tenantScope(id int) Scope {
+	return NewScope("tenant", "{table}.tenant_id = :tenant_id", Params{"tenant_id": id}).
+		ForTables("users", "orders")
+}
+
+var softDeleteScope = NewScope("soft_delete", "{table}.deleted_at IS NULL").ForTables("users")
+
+func TestScoped_From(t *testing.T) {
+	q, params, err := New().
+		Select("id").
+		From("users u").
+		Where("u.active = :active", Params{"active": true}).
+		Scoped(tenantScope(7), softDeleteScope).
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "SELECT id FROM users u WHERE u.active = :active AND u.tenant_id = :tenant_id AND u.deleted_at IS NULL"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+	assertParam(t, params, "active", true)
+	assertParam(t, params, "tenant_id", 7)
+}
+
+func TestScoped_NoWheresAndUnaliasedTable(t *testing.T) {
+	q, _, err := New().
+		Select("id").
+		From("public.users").
+		Scoped(softDeleteScope).
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "SELECT id FROM public.users WHERE public.users.deleted_at IS NULL"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+}
+
+func TestScoped_OtherTablesUntouched(t *testing.T) {
+	q, _, err := New().
+		Select("id").
+		From("products AS p").
+		Scoped(tenantScope(7)).
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "SELECT id FROM products AS p"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+}
+
+func TestScoped_OrIsParenthesized(t *testing.T) {
+	q, _, err := New().
+		Select("id").
+		From("users u").
+		Where("u.role = 'admin' OR u.role = 'owner'").
+		Scoped(tenantScope(7)).
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "SELECT id FROM users u WHERE (u.role = 'admin' OR u.role = 'owner') AND u.tenant_id = :tenant_id"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+}
+
+func TestScoped_Joins(t *testing.T) {
+	q, _, err := New().
+		Select("u.id", "o.id").
+		From("users u").
+		LeftJoin("orders o ON o.user_id = u.id").
+		CrossJoin("users m").
+		InnerJoin("products p USING (product_id)").
+		Scoped(tenantScope(7)).
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "SELECT u.id, o.id FROM users u" +
+		" LEFT JOIN orders o ON o.user_id = u.id AND o.tenant_id = :tenant_id" +
+		" CROSS JOIN users m" +
+		" INNER JOIN products p USING (product_id)" +
+		" WHERE u.tenant_id = :tenant_id AND m.tenant_id = :tenant_id"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+}
+
+func TestScoped_RightAndFullJoins(t *testing.T) {
+	q, _, err := New().
+		Select("*").
+		From("orders o").
+		RightJoin("users u ON u.id = o.user_id").
+		Scoped(NewScope("tenant", "{table}.tenant_id = :tenant_id", Params{"tenant_id": 7}).ForTables("users")).
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "SELECT * FROM orders o RIGHT JOIN users u ON u.id = o.user_id WHERE u.tenant_id = :tenant_id"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+
+	q, _, err = New().
+		Select("*").
+		From("users u").
+		FullJoin("orders o ON o.user_id = u.id").
+		Where("u.active OR o.open").
+		Scoped(tenantScope(7)).
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected = "SELECT * FROM users u FULL JOIN orders o ON o.user_id = u.id" +
+		" WHERE (u.active OR o.open) AND u.tenant_id = :tenant_id AND o.tenant_id = :tenant_id"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+}
+
+func TestScoped_TableRefs(t *testing.T) {
+	users := NewScope("tenant", "{table}.tenant_id = :tenant_id", Params{"tenant_id": 7}).ForTables("users")
+
+	tests := []struct {
+		q    Query
+		want string
+	}{
+		{
+			New().Select("*").From("ONLY users u").Scoped(users),
+			"SELECT * FROM ONLY users u WHERE u.tenant_id = :tenant_id",
+		},
+		{
+			New().Select("*").From("Users u").Scoped(users),
+			"SELECT * FROM Users u WHERE u.tenant_id = :tenant_id",
+		},
+		{
+			New().Select("*").From(`"users" u`).Scoped(users),
+			`SELECT * FROM "users" u WHERE u.tenant_id = :tenant_id`,
+		},
+		{
+			New().Select("*").From(`"public"."users"`).Scoped(users),
+			`SELECT * FROM "public"."users" WHERE "public"."users".tenant_id = :tenant_id`,
+		},
+		{
+			NewUpdate().Table("ONLY users").Set("active = false").All().Scoped(users),
+			"UPDATE ONLY users SET active = false WHERE users.tenant_id = :tenant_id",
+		},
+	}
+	for _, tt := range tests {
+		got, _, err := tt.q.Build()
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("SQL mismatch\n got: %s\nwant: %s", got, tt.want)
+		}
+	}
+}
+
+func TestScoped_NonTableRefsFail(t *testing.T) {
+	allTables := NewScope("soft_delete", "{table}.deleted_at IS NULL")
+	q := New().
+		Select("*").
+		From("ONLY users u").
+		LeftJoin("LATERAL (SELECT 1 AS n) x ON true").
+		CrossJoin("generate_series(1, 3) g")
+
+	_, _, err := q.Scoped(allTables).Build()
+	var be *BuildError
+	if !errors.As(err, &be) || be.Method != "LeftJoin" || be.Index != 0 {
+		t.Errorf("expected LeftJoin #0 BuildError, got: %v", err)
+	}
+	if !errors.Is(err, ErrScopedTableRef) {
+		t.Errorf("expected ErrScopedTableRef, got: %v", err)
+	}
+
+	got, _, err := q.Scoped(allTables).Unscoped("soft_delete").Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "SELECT * FROM ONLY users u LEFT JOIN LATERAL (SELECT 1 AS n) x ON true CROSS JOIN generate_series(1, 3) g"
+	if got != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", got, expected)
+	}
+
+	b, err := Parse("SELECT * FROM users u LEFT JOIN LATERAL (SELECT 1 AS n) x ON true")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	if _, _, err = b.Scoped(allTables).Build(); !errors.Is(err, ErrScopedTableRef) {
+		t.Errorf("expected ErrScopedTableRef for a parsed LATERAL join, got: %v", err)
+	}
+
+	_, _, err = New().Select("*").ReplaceFrom("generate_series(1, 3) AS s(i)").Scoped(allTables).Build()
+	if !errors.Is(err, ErrScopedTableRef) {
+		t.Errorf("expected ErrScopedTableRef for a table function, got: %v", err)
+	}
+}
+
+func TestScoped_Subqueries(t *testing.T) {
+	sub := New().Select("user_id").From("orders")
+	lateral := New().Select("total").From("orders o2").Where("o2.user_id = u.id")
+
+	q, _, err := New().
+		Select("u.id").
+		From("users u").
+		LeftJoinLateral(lateral, "latest", "true").
+		WhereIn("u.id", sub).
+		Scoped(tenantScope(7)).
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "SELECT u.id FROM users u" +
+		" LEFT JOIN LATERAL (SELECT total FROM orders o2 WHERE o2.user_id = u.id AND o2.tenant_id = :tenant_id) latest ON true" +
+		" WHERE u.id IN (SELECT user_id FROM orders WHERE orders.tenant_id = :tenant_id) AND u.tenant_id = :tenant_id"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+}
+
+func TestUnscoped(t *testing.T) {
+	base := New().Select("id").From("users").Scoped(tenantScope(7), softDeleteScope)
+
+	q, _, err := base.Unscoped("soft_delete").Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "SELECT id FROM users WHERE users.tenant_id = :tenant_id"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+
+	q, _, err = base.Unscoped().Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected = "SELECT id FROM users"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+}
+
+func TestUnscoped_Subquery(t *testing.T) {
+	sub := New().Select("user_id").From("users").Unscoped("soft_delete")
+
+	q, _, err := New().
+		Select("id").
+		From("users").
+		WhereExists(sub).
+		Scoped(softDeleteScope).
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "SELECT id FROM users WHERE EXISTS (SELECT user_id FROM users) AND users.deleted_at IS NULL"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+}
+
+func TestSetDefaultScopes(t *testing.T) {
+	SetDefaultScopes(softDeleteScope)
+	t.Cleanup(func() { SetDefaultScopes() })
+
+	q, _, err := New().Select("id").From("users").Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "SELECT id FROM users WHERE users.deleted_at IS NULL"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+
+	q, _, err = New().Select("id").From("users").Unscoped("soft_delete").Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected = "SELECT id FROM users"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+
+	// A scope of the same name replaces the default.
+	archived := NewScope("soft_delete", "{table}.deleted_at IS NOT NULL").ForTables("users")
+	q, _, err = New().Select("id").From("users").Scoped(archived).Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected = "SELECT id FROM users WHERE users.deleted_at IS NOT NULL"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+}
+
+func TestScoped_Update(t *testing.T) {
+	q, params, err := NewUpdate().
+		Table("users").
+		Set("name = :name", Params{"name": "Alice"}).
+		Where("id = :id", Params{"id": 1}).
+		Scoped(tenantScope(7)).
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "UPDATE users SET name = :name WHERE id = :id AND users.tenant_id = :tenant_id"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+	assertParam(t, params, "tenant_id", 7)
+}
+
+func TestScoped_UpdateStillRequiresWhere(t *testing.T) {
+	_, _, err := NewUpdate().
+		Table("users").
+		Set("name = :name", Params{"name": "Alice"}).
+		Scoped(tenantScope(7)).
+		Build()
+
+	if !errors.Is(err, ErrUpdateNoWhere) {
+		t.Errorf("expected ErrUpdateNoWhere, got: %v", err)
+	}
+}
+
+func TestScoped_DeleteAll(t *testing.T) {
+	q, _, err := NewDelete().
+		From("orders").
+		All().
+		Scoped(tenantScope(7)).
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "DELETE FROM orders WHERE orders.tenant_id = :tenant_id"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+}
+
+func TestScoped_DeleteSubquery(t *testing.T) {
+	sub := New().Select("id").From("users").Where("banned = :banned", Params{"banned": true})
+
+	q, _, err := NewDelete().
+		From("orders").
+		WhereIn("user_id", sub).
+		Scoped(tenantScope(7)).
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "DELETE FROM orders WHERE user_id IN (SELECT id FROM users WHERE banned = :banned AND users.tenant_id = :tenant_id) AND orders.tenant_id = :tenant_id"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+}
+
+func TestScoped_InsertSelectUsesDefaults(t *testing.T) {
+	SetDefaultScopes(softDeleteScope)
+	t.Cleanup(func() { SetDefaultScopes() })
+
+	q, _, err := NewInsert().
+		Into("archive").
+		Columns("id").
+		Select(New().Select("id").From("users")).
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "INSERT INTO archive (id) SELECT id FROM users WHERE users.deleted_at IS NULL"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+}
+
+func TestScoped_Errors(t *testing.T) {
+	bad := NewScope("tenant", "{table}.tenant_id = :tenant_id").ForTables("users")
+
+	_, _, err := New().Select("id").From("users").Scoped(bad).Build()
+
+	var be *BuildError
+	if !errors.As(err, &be) || be.Method != "Scoped" {
+		t.Errorf("expected Scoped BuildError, got: %v", err)
+	}
+	if !errors.Is(err, ErrMissingParam) {
+		t.Errorf("expected ErrMissingParam, got: %v", err)
+	}
+
+	_, _, err = New().
+		Select("id").
+		From("users").
+		Where("id = @id", Params{"id": 1}).
+		Scoped(tenantScope(7)).
+		Build()
+	if !errors.Is(err, ErrMixedPrefix) {
+		t.Errorf("expected ErrMixedPrefix, got: %v", err)
+	}
+}
+
+func TestScoped_Immutability(t *testing.T) {
+	base := New().Select("id").From("users")
+	_ = base.Scoped(tenantScope(7))
+
+	q, _, err := base.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if q != "SELECT id FROM users" {
+		t.Errorf("base builder was mutated: %s", q)
+	}
+}
+
+func TestSplitJoin(t *testing.T) {
+	tests := []struct {
+		sql, ref, on string
+		hasOn        bool
+	}{
+		{"orders o ON o.user_id = u.id", "orders o", "o.user_id = u.id", true},
+		{"orders AS o on (o.user_id = u.id)", "orders AS o", "(o.user_id = u.id)", true},
+		{"orders o USING (user_id)", "orders o", "", false},
+		{"(SELECT 1 ON x) s ON true", "(SELECT 1 ON x) s", "true", true},
+		{"users m", "users m", "", false},
+		{"online o ON o.id = u.id", "online o", "o.id = u.id", true},
+	}
+	for _, tt := range tests {
+		ref, on, hasOn := splitJoin(tt.sql)
+		if ref != tt.ref || on != tt.on || hasOn != tt.hasOn {
+			t.Errorf("splitJoin(%q) = (%q, %q, %v), want (%q, %q, %v)", tt.sql, ref, on, hasOn, tt.ref, tt.on, tt.hasOn)
+		}
+	}
+}
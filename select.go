@@ -1,10 +1,5 @@
 package squildx
 
-import (
-	"reflect"
-	"strings"
-)
-
 func (b *builder) Select(columns ...string) Builder {
 	cp := b.clone()
 	cp.columns = append(cp.columns, columns...)
@@ -17,134 +12,43 @@ func (b *builder) SelectObject(obj any, table ...string) Builder {
 	if len(table) > 0 {
 		prefix = table[0]
 	}
-	cols, err := structColumns(obj, prefix)
+	cols, err := structColumns(obj, prefix, 0, cp.naming)
 	if err != nil {
-		cp.err = err
+		cp.failAdd("columns", "SelectObject", len(cp.columns), "", err)
 		return cp
 	}
 	cp.columns = append(cp.columns, cols...)
 	return cp
 }
 
-func structColumns(obj any, table string) ([]string, error) {
-	t := reflect.TypeOf(obj)
-	if t == nil {
-		return nil, ErrNotAStruct
-	}
-	for t.Kind() == reflect.Ptr {
-		t = t.Elem()
-	}
-	if t.Kind() != reflect.Struct {
-		return nil, ErrNotAStruct
-	}
-
-	var cols []string
-	collectColumns(t, table, &cols)
-	return cols, nil
-}
-
-func collectColumns(t reflect.Type, table string, cols *[]string) {
-	for i := range t.NumField() {
-		f := t.Field(i)
-		if !f.IsExported() {
-			continue
-		}
-
-		ft := f.Type
-		for ft.Kind() == reflect.Ptr {
-			ft = ft.Elem()
-		}
-
-		tagName := fieldTagName(f)
-		if tagName == "-" {
-			continue
-		}
-
-		if f.Anonymous && ft.Kind() == reflect.Struct && tagName == "" {
-			collectColumns(ft, table, cols)
-			continue
-		}
-
-		name := tagName
-		if name == "" {
-			name = toSnakeCase(f.Name)
-		}
-		if table != "" {
-			name = table + "." + name
-		}
-		*cols = append(*cols, name)
-	}
-}
-
-func structValues(obj any) (Params, error) {
-	v := reflect.ValueOf(obj)
-	t := reflect.TypeOf(obj)
-	if t == nil {
-		return nil, ErrNotAStruct
-	}
-	for t.Kind() == reflect.Ptr {
-		t = t.Elem()
-		v = v.Elem()
-	}
-	if t.Kind() != reflect.Struct {
-		return nil, ErrNotAStruct
+// SelectObjectAs selects the columns of obj qualified with table and aliased
+// under prefix, following the sqlx convention for scanning into nested
+// structs: SelectObjectAs(User{}, "u", "user") yields u.id AS "user.id".
+func (b *builder) SelectObjectAs(obj any, table, prefix string) Builder {
+	cp := b.clone()
+	cols, err := structColumnsAs(obj, table, prefix, 0, cp.naming)
+	if err != nil {
+		cp.failAdd("columns", "SelectObjectAs", len(cp.columns), "", err)
+		return cp
 	}
-
-	params := Params{}
-	collectValues(t, v, params)
-	return params, nil
+	cp.columns = append(cp.columns, cols...)
+	return cp
 }
 
-func collectValues(t reflect.Type, v reflect.Value, params Params) {
-	for i := range t.NumField() {
-		f := t.Field(i)
-		if !f.IsExported() {
-			continue
-		}
-
-		ft := f.Type
-		fv := v.Field(i)
-		for ft.Kind() == reflect.Ptr {
-			if fv.IsNil() {
-				break
-			}
-			ft = ft.Elem()
-			fv = fv.Elem()
-		}
-
-		tagName := fieldTagName(f)
-		if tagName == "-" {
-			continue
-		}
-
-		if f.Anonymous && ft.Kind() == reflect.Struct && tagName == "" {
-			collectValues(ft, fv, params)
-			continue
-		}
-
-		name := tagName
-		if name == "" {
-			name = toSnakeCase(f.Name)
-		}
-		params[name] = fv.Interface()
-	}
+// structColumns returns the column names of obj, qualified with table when it
+// is non-empty. Fields carrying any of the skip tag options are left out.
+// The returned slice is cached and must not be modified.
+func structColumns(obj any, table string, skip tagOptions, ns NamingStrategy) ([]string, error) {
+	return structColumnsAs(obj, table, "", skip, ns)
 }
 
-func fieldTagName(f reflect.StructField) string {
-	for _, tag := range []string{"squildx", "db", "json"} {
-		v, ok := f.Tag.Lookup(tag)
-		if !ok {
-			continue
-		}
-		v, _, _ = strings.Cut(v, ",")
-		if v == "-" {
-			return "-"
-		}
-		if v != "" {
-			return v
-		}
+// structColumnsAs is structColumns with every column aliased under prefix.
+func structColumnsAs(obj any, table, prefix string, skip tagOptions, ns NamingStrategy) ([]string, error) {
+	t, err := structType(obj)
+	if err != nil {
+		return nil, err
 	}
-	return ""
+	return structMetaOf(t, ns).columnNames(table, prefix, skip), nil
 }
 
 func (b *builder) RemoveSelect(columns ...string) Builder {
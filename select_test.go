@@ -421,3 +421,180 @@ func TestSelectObjectEmbeddedWithDbDash(t *testing.T) {
 		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
 	}
 }
+
+func TestTagOptionsIgnoredForColumnName(t *testing.T) {
+	type User struct {
+		ID   int    `squildx:"id,immutable"`
+		Name string `db:"name" squildx:",immutable"`
+		Bio  string `json:"bio,omitempty"`
+	}
+
+	q, _, err := New().SelectObject(User{}).From("users").Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "SELECT id, name, bio FROM users"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+}
+
+func TestSelectObjectIncludesAllTagOptions(t *testing.T) {
+	type User struct {
+		ID     int    `squildx:"id,pk,readonly"`
+		Search string `squildx:"search,omitinsert"`
+		Role   string `squildx:"role,default,omitempty"`
+	}
+
+	q, _, err := New().SelectObject(User{}).From("users").Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "SELECT id, search, role FROM users"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+}
+
+func TestSelectObjectAs(t *testing.T) {
+	type User struct {
+		ID   int    `db:"id"`
+		Name string `db:"name"`
+	}
+
+	q, _, err := New().
+		SelectObjectAs(User{}, "u", "user").
+		From("users u").
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `SELECT u.id AS "user.id", u.name AS "user.name" FROM users u`
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+}
+
+func TestSelectObjectAs_NoTable(t *testing.T) {
+	type User struct {
+		ID int `db:"id"`
+	}
+
+	q, _, err := New().SelectObjectAs(User{}, "", "user").From("users").Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `SELECT id AS "user.id" FROM users`
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+}
+
+func TestSelectObjectAs_JoinedStructs(t *testing.T) {
+	type User struct {
+		ID   int    `db:"id"`
+		Name string `db:"name"`
+	}
+	type Order struct {
+		ID    int `db:"id"`
+		Total int `db:"total"`
+	}
+
+	q, _, err := New().
+		SelectObjectAs(Order{}, "o", "order").
+		SelectObjectAs(User{}, "u", "user").
+		From("orders o").
+		InnerJoin("users u ON u.id = o.user_id").
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `SELECT o.id AS "order.id", o.total AS "order.total", u.id AS "user.id", u.name AS "user.name" FROM orders o INNER JOIN users u ON u.id = o.user_id`
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+}
+
+func TestSelectObjectAs_NotAStruct(t *testing.T) {
+	_, _, err := New().SelectObjectAs(42, "u", "user").From("users").Build()
+	if !errors.Is(err, ErrNotAStruct) {
+		t.Errorf("expected ErrNotAStruct, got: %v", err)
+	}
+}
+
+type prefixUser struct {
+	ID   int    `db:"id"`
+	Name string `db:"name"`
+}
+
+type prefixOrder struct {
+	ID       int         `db:"id"`
+	Total    int         `db:"total"`
+	User     prefixUser  `squildx:"user,prefix,table=u"`
+	Customer *prefixUser `squildx:"customer,prefix"`
+}
+
+func TestSelectObjectNestedPrefix(t *testing.T) {
+	q, _, err := New().
+		SelectObject(prefixOrder{}, "o").
+		From("orders o").
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `SELECT o.id, o.total, u.id AS "user.id", u.name AS "user.name", customer.id AS "customer.id", customer.name AS "customer.name" FROM orders o`
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+}
+
+func TestSelectObjectAsNestedPrefix(t *testing.T) {
+	type Row struct {
+		Order prefixOrder `squildx:"order,prefix,table=o"`
+	}
+
+	q, _, err := New().
+		SelectObjectAs(prefixOrder{}, "o", "order").
+		From("orders o").
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `SELECT o.id AS "order.id", o.total AS "order.total", u.id AS "order.user.id", u.name AS "order.user.name", customer.id AS "order.customer.id", customer.name AS "order.customer.name" FROM orders o`
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+
+	q, _, err = New().SelectObject(Row{}).From("orders o").Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if q != expected {
+		t.Errorf("nested prefix struct should match SelectObjectAs\n got: %s\nwant: %s", q, expected)
+	}
+}
+
+func TestNestedPrefixFieldsNotWritten(t *testing.T) {
+	o := prefixOrder{ID: 1, Total: 10, User: prefixUser{ID: 2}}
+
+	q, params, err := NewInsert().Into("orders").ValuesObject(o).ReturningObject(o).Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "INSERT INTO orders (id, total) VALUES (:id, :total) RETURNING id, total"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+	if len(params) != 2 {
+		t.Errorf("expected 2 params, got %d: %v", len(params), params)
+	}
+
+	q, _, err = NewUpdate().Table("orders").SetObject(o).Where("id = 1").Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected = "UPDATE orders SET id = :id, total = :total WHERE id = 1"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+}
@@ -0,0 +1,25 @@
+package squildx
+
+import "strings"
+
+func (b *truncateBuilder) Build() (string, Params, error) {
+	r := newRenderer(0, nil)
+
+	if len(b.tables) == 0 {
+		r.fail(ErrTruncateNoTable)
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("TRUNCATE ")
+	sb.WriteString(strings.Join(b.tables, ", "))
+
+	if b.restartIdentity {
+		sb.WriteString(" RESTART IDENTITY")
+	}
+	if b.cascade {
+		sb.WriteString(" CASCADE")
+	}
+
+	return r.result(sb.String())
+}
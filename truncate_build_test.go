@@ -0,0 +1,47 @@
+package squildx
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTruncateBuild_NoTable(t *testing.T) {
+	_, _, err := NewTruncate().Cascade().Build()
+
+	if !errors.Is(err, ErrTruncateNoTable) {
+		t.Errorf("expected ErrTruncateNoTable, got: %v", err)
+	}
+}
+
+func TestTruncateBuild(t *testing.T) {
+	q, params, err := NewTruncate().Table("users").Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "TRUNCATE users"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+	if len(params) != 0 {
+		t.Errorf("expected 0 params, got %d", len(params))
+	}
+}
+
+func TestTruncateBuild_Full(t *testing.T) {
+	q, _, err := NewTruncate().
+		Table("users", "orders").
+		RestartIdentity().
+		Cascade().
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "TRUNCATE users, orders RESTART IDENTITY CASCADE"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+}
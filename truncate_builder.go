@@ -0,0 +1,25 @@
+package squildx
+
+// TruncateBuilder provides a fluent, immutable API for constructing TRUNCATE queries.
+type TruncateBuilder interface {
+	Table(tables ...string) TruncateBuilder
+	RestartIdentity() TruncateBuilder
+	Cascade() TruncateBuilder
+	Build() (string, Params, error)
+}
+
+type truncateBuilder struct {
+	tables          []string
+	restartIdentity bool
+	cascade         bool
+}
+
+func NewTruncate() TruncateBuilder {
+	return &truncateBuilder{}
+}
+
+func (b *truncateBuilder) clone() *truncateBuilder {
+	cp := *b
+	cp.tables = copySlice(b.tables)
+	return &cp
+}
@@ -0,0 +1,19 @@
+package squildx
+
+func (b *truncateBuilder) Table(tables ...string) TruncateBuilder {
+	cp := b.clone()
+	cp.tables = append(cp.tables, tables...)
+	return cp
+}
+
+func (b *truncateBuilder) RestartIdentity() TruncateBuilder {
+	cp := b.clone()
+	cp.restartIdentity = true
+	return cp
+}
+
+func (b *truncateBuilder) Cascade() TruncateBuilder {
+	cp := b.clone()
+	cp.cascade = true
+	return cp
+}
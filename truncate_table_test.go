@@ -0,0 +1,27 @@
+package squildx
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTruncateTable(t *testing.T) {
+	b := NewTruncate().Table("users").Table("orders", "sessions")
+	tb := b.(*truncateBuilder)
+	want := []string{"users", "orders", "sessions"}
+	if !reflect.DeepEqual(tb.tables, want) {
+		t.Errorf("tables = %v, want %v", tb.tables, want)
+	}
+}
+
+func TestTruncateTable_Immutability(t *testing.T) {
+	base := NewTruncate().Table("users")
+	_ = base.Table("orders").RestartIdentity().Cascade()
+	tb := base.(*truncateBuilder)
+	if !reflect.DeepEqual(tb.tables, []string{"users"}) {
+		t.Errorf("base tables mutated to %v", tb.tables)
+	}
+	if tb.restartIdentity || tb.cascade {
+		t.Error("base options mutated")
+	}
+}
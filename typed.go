@@ -0,0 +1,319 @@
+package squildx
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"iter"
+	"reflect"
+	"slices"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// TypedSelect is a SELECT whose rows scan into T. It wraps a Builder and
+// offers the same chainable methods, so the struct type is named only once:
+//
+//	users, err := squildx.NewTypedSelect[User]().
+//		From("users").
+//		Where("active = :active", squildx.Params{"active": true}).
+//		All(ctx, db)
+//
+// Queries run through sqlx named queries, which require the : prefix. Rows
+// scan by the same column names SelectObject selects, so squildx tags, the
+// naming strategy and prefix structs all apply.
+type TypedSelect[T any] struct {
+	b     Builder
+	table []string       // table qualifying the columns of T
+	ns    NamingStrategy // strategy the columns of T were selected with
+}
+
+// NewTypedSelect returns a TypedSelect selecting the columns of T, as
+// SelectObject(T{}, table...) does.
+func NewTypedSelect[T any](table ...string) TypedSelect[T] {
+	var zero T
+	return TypedSelect[T]{b: New().SelectObject(zero, table...), table: table, ns: resolveNaming(nil)}
+}
+
+func (q TypedSelect[T]) with(b Builder) TypedSelect[T] {
+	q.b = b
+	return q
+}
+
+// Builder returns the underlying Builder, e.g. to use the query as a subquery.
+func (q TypedSelect[T]) Builder() Builder {
+	return q.b
+}
+
+func (q TypedSelect[T]) Build() (string, Params, error) {
+	return q.b.Build()
+}
+
+func (q TypedSelect[T]) BuildArgs() (string, []any, error) {
+	return q.b.BuildArgs()
+}
+
+func (q TypedSelect[T]) Compile() (*Template, error) {
+	return q.b.Compile()
+}
+
+func (q TypedSelect[T]) Select(columns ...string) TypedSelect[T] {
+	return q.with(q.b.Select(columns...))
+}
+
+func (q TypedSelect[T]) SelectObject(obj any, table ...string) TypedSelect[T] {
+	return q.with(q.b.SelectObject(obj, table...))
+}
+
+func (q TypedSelect[T]) SelectObjectAs(obj any, table, prefix string) TypedSelect[T] {
+	return q.with(q.b.SelectObjectAs(obj, table, prefix))
+}
+
+func (q TypedSelect[T]) RemoveSelect(columns ...string) TypedSelect[T] {
+	return q.with(q.b.RemoveSelect(columns...))
+}
+
+func (q TypedSelect[T]) Distinct() TypedSelect[T] {
+	return q.with(q.b.Distinct())
+}
+
+func (q TypedSelect[T]) From(table string) TypedSelect[T] {
+	return q.with(q.b.From(table))
+}
+
+func (q TypedSelect[T]) ReplaceFrom(table string, params ...Params) TypedSelect[T] {
+	return q.with(q.b.ReplaceFrom(table, params...))
+}
+
+func (q TypedSelect[T]) InnerJoin(sql string, params ...Params) TypedSelect[T] {
+	return q.with(q.b.InnerJoin(sql, params...))
+}
+
+func (q TypedSelect[T]) LeftJoin(sql string, params ...Params) TypedSelect[T] {
+	return q.with(q.b.LeftJoin(sql, params...))
+}
+
+func (q TypedSelect[T]) RightJoin(sql string, params ...Params) TypedSelect[T] {
+	return q.with(q.b.RightJoin(sql, params...))
+}
+
+func (q TypedSelect[T]) FullJoin(sql string, params ...Params) TypedSelect[T] {
+	return q.with(q.b.FullJoin(sql, params...))
+}
+
+func (q TypedSelect[T]) CrossJoin(sql string, params ...Params) TypedSelect[T] {
+	return q.with(q.b.CrossJoin(sql, params...))
+}
+
+func (q TypedSelect[T]) InnerJoinLateral(sub Builder, alias string, on string, params ...Params) TypedSelect[T] {
+	return q.with(q.b.InnerJoinLateral(sub, alias, on, params...))
+}
+
+func (q TypedSelect[T]) LeftJoinLateral(sub Builder, alias string, on string, params ...Params) TypedSelect[T] {
+	return q.with(q.b.LeftJoinLateral(sub, alias, on, params...))
+}
+
+func (q TypedSelect[T]) CrossJoinLateral(sub Builder, alias string) TypedSelect[T] {
+	return q.with(q.b.CrossJoinLateral(sub, alias))
+}
+
+func (q TypedSelect[T]) Where(sql string, params ...Params) TypedSelect[T] {
+	return q.with(q.b.Where(sql, params...))
+}
+
+func (q TypedSelect[T]) WhereTagged(tag, sql string, params ...Params) TypedSelect[T] {
+	return q.with(q.b.WhereTagged(tag, sql, params...))
+}
+
+func (q TypedSelect[T]) RemoveWhere(tag string) TypedSelect[T] {
+	return q.with(q.b.RemoveWhere(tag))
+}
+
+func (q TypedSelect[T]) WhereExists(sub Builder) TypedSelect[T] {
+	return q.with(q.b.WhereExists(sub))
+}
+
+func (q TypedSelect[T]) WhereNotExists(sub Builder) TypedSelect[T] {
+	return q.with(q.b.WhereNotExists(sub))
+}
+
+func (q TypedSelect[T]) WhereIn(column string, sub Builder) TypedSelect[T] {
+	return q.with(q.b.WhereIn(column, sub))
+}
+
+func (q TypedSelect[T]) WhereNotIn(column string, sub Builder) TypedSelect[T] {
+	return q.with(q.b.WhereNotIn(column, sub))
+}
+
+func (q TypedSelect[T]) GroupBy(exprs ...string) TypedSelect[T] {
+	return q.with(q.b.GroupBy(exprs...))
+}
+
+func (q TypedSelect[T]) Having(sql string, params ...Params) TypedSelect[T] {
+	return q.with(q.b.Having(sql, params...))
+}
+
+func (q TypedSelect[T]) OrderBy(expr string, params ...Params) TypedSelect[T] {
+	return q.with(q.b.OrderBy(expr, params...))
+}
+
+func (q TypedSelect[T]) ClearOrderBy() TypedSelect[T] {
+	return q.with(q.b.ClearOrderBy())
+}
+
+func (q TypedSelect[T]) Limit(n uint64) TypedSelect[T] {
+	return q.with(q.b.Limit(n))
+}
+
+func (q TypedSelect[T]) ClearLimit() TypedSelect[T] {
+	return q.with(q.b.ClearLimit())
+}
+
+func (q TypedSelect[T]) Offset(n uint64) TypedSelect[T] {
+	return q.with(q.b.Offset(n))
+}
+
+// Naming sets the strategy of the underlying builder and selects the columns
+// of T by it instead, so that rows scan by the names selected.
+func (q TypedSelect[T]) Naming(ns NamingStrategy) TypedSelect[T] {
+	var zero T
+	cp := q.with(q.b.Naming(ns))
+	b, ok := cp.b.(*builder)
+	if !ok {
+		return cp
+	}
+	old := New().Naming(q.ns).SelectObject(zero, q.table...).Columns()
+	at := -1
+	if len(old) > 0 {
+		at = slices.Index(b.columns, old[0])
+	}
+	if at < 0 || len(b.columns) < at+len(old) || !slices.Equal(b.columns[at:at+len(old)], old) {
+		return cp // the columns of T were changed; leave them
+	}
+	cp.ns = resolveNaming(ns)
+	columns := New().Naming(cp.ns).SelectObject(zero, q.table...).Columns()
+	b.columns = slices.Replace(b.columns, at, at+len(old), columns...)
+	return cp
+}
+
+func (q TypedSelect[T]) Scoped(scopes ...Scope) TypedSelect[T] {
+	return q.with(q.b.Scoped(scopes...))
+}
+
+func (q TypedSelect[T]) Unscoped(names ...string) TypedSelect[T] {
+	return q.with(q.b.Unscoped(names...))
+}
+
+func (q TypedSelect[T]) Columns() []string {
+	return q.b.Columns()
+}
+
+func (q TypedSelect[T]) FromTable() string {
+	return q.b.FromTable()
+}
+
+func (q TypedSelect[T]) Joins() []Clause {
+	return q.b.Joins()
+}
+
+func (q TypedSelect[T]) Wheres() []Clause {
+	return q.b.Wheres()
+}
+
+func (q TypedSelect[T]) OrderBys() []Clause {
+	return q.b.OrderBys()
+}
+
+func (q TypedSelect[T]) LimitValue() (uint64, bool) {
+	return q.b.LimitValue()
+}
+
+// All runs the query and scans every row.
+func (q TypedSelect[T]) All(ctx context.Context, db sqlx.ExtContext) ([]T, error) {
+	var out []T
+	for v, err := range q.Iter(ctx, db) {
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+// One runs the query and scans its first row. It returns sql.ErrNoRows when
+// the query returns no rows.
+func (q TypedSelect[T]) One(ctx context.Context, db sqlx.ExtContext) (T, error) {
+	for v, err := range q.Iter(ctx, db) {
+		return v, err
+	}
+	var zero T
+	return zero, sql.ErrNoRows
+}
+
+// Iter runs the query and yields rows as they are scanned. A build, query or
+// scan error is yielded once, with the zero T, and ends the iteration.
+// Breaking out of the loop closes the rows.
+func (q TypedSelect[T]) Iter(ctx context.Context, db sqlx.ExtContext) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		query, params, err := q.b.Build()
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		rows, err := sqlx.NamedQueryContext(ctx, db, query, map[string]any(params))
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		defer rows.Close()
+		columns, err := rows.Columns()
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		fields, err := scanFields(reflect.TypeFor[T](), q.ns, columns)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		dest := make([]any, len(fields))
+		for rows.Next() {
+			var v T
+			rv := reflect.ValueOf(&v).Elem()
+			for i, f := range fields {
+				dest[i] = f.addr(rv)
+			}
+			if err := rows.Scan(dest...); err != nil {
+				yield(zero, err)
+				return
+			}
+			if !yield(v, nil) {
+				return
+			}
+		}
+		if err := rows.Err(); err != nil {
+			yield(zero, err)
+		}
+	}
+}
+
+// scanFields returns the field of struct type t that each column scans into.
+// Columns are matched by name, with nested fields of prefix structs matched by
+// their dotted alias, e.g. "user.id". A column without a field fails with
+// ErrScanColumn.
+func scanFields(t reflect.Type, ns NamingStrategy, columns []string) ([]structField, error) {
+	byName := make(map[string]structField)
+	for _, f := range structFields(t, ns) {
+		byName[f.path+f.name] = f
+	}
+	fields := make([]structField, len(columns))
+	for i, col := range columns {
+		f, ok := byName[col]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrScanColumn, col)
+		}
+		fields[i] = f
+	}
+	return fields, nil
+}
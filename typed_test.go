@@ -0,0 +1,324 @@
+package squildx
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// fakeDriver serves the rows of the fakeConn registered under the DSN and
+// records the queries it receives.
+type fakeDriver struct{}
+
+type fakeConn struct {
+	mu      sync.Mutex
+	columns []string
+	rows    [][]driver.Value
+	queries []string
+	args    [][]driver.NamedValue
+}
+
+var fakeConns sync.Map // dsn -> *fakeConn
+
+func init() {
+	sql.Register("squildxfake", fakeDriver{})
+}
+
+func (fakeDriver) Open(dsn string) (driver.Conn, error) {
+	c, ok := fakeConns.Load(dsn)
+	if !ok {
+		return nil, errors.New("fake: unknown dsn " + dsn)
+	}
+	return c.(*fakeConn), nil
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("fake: not supported")
+}
+func (c *fakeConn) Close() error              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) { return nil, errors.New("fake: not supported") }
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.queries = append(c.queries, query)
+	c.args = append(c.args, args)
+	return &fakeRows{columns: c.columns, rows: c.rows}, nil
+}
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+// openFakeDB returns a sqlx.DB whose queries return rows.
+func openFakeDB(t *testing.T, columns []string, rows ...[]driver.Value) (*sqlx.DB, *fakeConn) {
+	t.Helper()
+	conn := &fakeConn{columns: columns, rows: rows}
+	fakeConns.Store(t.Name(), conn)
+	t.Cleanup(func() { fakeConns.Delete(t.Name()) })
+
+	db, err := sqlx.Open("squildxfake", t.Name())
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db, conn
+}
+
+type typedUser struct {
+	ID   int64  `db:"id"`
+	Name string `db:"name"`
+}
+
+func TestTypedSelect_Build(t *testing.T) {
+	q, params, err := NewTypedSelect[typedUser]("u").
+		From("users u").
+		Where("u.active = :active", Params{"active": true}).
+		OrderBy("u.name").
+		Limit(10).
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "SELECT u.id, u.name FROM users u WHERE u.active = :active ORDER BY u.name LIMIT 10"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+	assertParam(t, params, "active", true)
+}
+
+func TestTypedSelect_All(t *testing.T) {
+	db, conn := openFakeDB(t, []string{"id", "name"},
+		[]driver.Value{int64(1), "Alice"},
+		[]driver.Value{int64(2), "Bob"},
+	)
+
+	users, err := NewTypedSelect[typedUser]().
+		From("users").
+		Where("active = :active", Params{"active": true}).
+		All(context.Background(), db)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(users) != 2 || users[0] != (typedUser{1, "Alice"}) || users[1] != (typedUser{2, "Bob"}) {
+		t.Errorf("unexpected users: %+v", users)
+	}
+	if len(conn.queries) != 1 || conn.queries[0] != "SELECT id, name FROM users WHERE active = ?" {
+		t.Errorf("unexpected queries: %q", conn.queries)
+	}
+	if len(conn.args[0]) != 1 || conn.args[0][0].Value != true {
+		t.Errorf("unexpected args: %v", conn.args[0])
+	}
+}
+
+func TestTypedSelect_One(t *testing.T) {
+	db, _ := openFakeDB(t, []string{"id", "name"}, []driver.Value{int64(1), "Alice"})
+
+	u, err := NewTypedSelect[typedUser]().From("users").Where("id = :id", Params{"id": 1}).One(context.Background(), db)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if u != (typedUser{1, "Alice"}) {
+		t.Errorf("unexpected user: %+v", u)
+	}
+}
+
+func TestTypedSelect_OneNoRows(t *testing.T) {
+	db, _ := openFakeDB(t, []string{"id", "name"})
+
+	_, err := NewTypedSelect[typedUser]().From("users").One(context.Background(), db)
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("expected sql.ErrNoRows, got: %v", err)
+	}
+}
+
+func TestTypedSelect_Iter(t *testing.T) {
+	db, _ := openFakeDB(t, []string{"id", "name"},
+		[]driver.Value{int64(1), "Alice"},
+		[]driver.Value{int64(2), "Bob"},
+		[]driver.Value{int64(3), "Carol"},
+	)
+
+	var names []string
+	for u, err := range NewTypedSelect[typedUser]().From("users").Iter(context.Background(), db) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		names = append(names, u.Name)
+		if len(names) == 2 {
+			break
+		}
+	}
+	if len(names) != 2 || names[0] != "Alice" || names[1] != "Bob" {
+		t.Errorf("unexpected names: %v", names)
+	}
+}
+
+func TestTypedSelect_ScanMapping(t *testing.T) {
+	type Account struct {
+		Plan string `db:"plan"`
+	}
+	type Owner struct {
+		UserID    int64 `squildx:"id,pk"`
+		FirstName string
+		Account   *Account `squildx:"account,prefix,table=a"`
+	}
+
+	q := NewTypedSelect[Owner]("u").From("users u").LeftJoin("accounts a ON a.user_id = u.id")
+	query, _, err := q.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `SELECT u.id, u.first_name, a.plan AS "account.plan" FROM users u LEFT JOIN accounts a ON a.user_id = u.id`
+	if query != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", query, expected)
+	}
+
+	db, _ := openFakeDB(t, []string{"id", "first_name", "account.plan"}, []driver.Value{int64(1), "Alice", "pro"})
+
+	o, err := q.One(context.Background(), db)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if o.UserID != 1 || o.FirstName != "Alice" || o.Account == nil || o.Account.Plan != "pro" {
+		t.Errorf("unexpected owner: %+v", o)
+	}
+}
+
+func TestTypedSelect_UnknownColumn(t *testing.T) {
+	db, _ := openFakeDB(t, []string{"id", "email"}, []driver.Value{int64(1), "a@example.com"})
+
+	_, err := NewTypedSelect[typedUser]().From("users").All(context.Background(), db)
+	if !errors.Is(err, ErrScanColumn) {
+		t.Errorf("expected ErrScanColumn, got: %v", err)
+	}
+}
+
+func TestTypedSelect_BuildError(t *testing.T) {
+	db, conn := openFakeDB(t, []string{"id", "name"})
+
+	_, err := NewTypedSelect[typedUser]().All(context.Background(), db)
+	if !errors.Is(err, ErrNoFrom) {
+		t.Errorf("expected ErrNoFrom, got: %v", err)
+	}
+	if len(conn.queries) != 0 {
+		t.Errorf("query should not run, got: %q", conn.queries)
+	}
+}
+
+func TestTypedSelect_Accessors(t *testing.T) {
+	q := NewTypedSelect[typedUser]().
+		From("users").
+		LeftJoin("orders o ON o.user_id = users.id").
+		WhereTagged("visible", "active = :active", Params{"active": true}).
+		OrderBy("name").
+		Limit(5)
+
+	if cols := q.Columns(); len(cols) != 2 || cols[0] != "id" || cols[1] != "name" {
+		t.Errorf("unexpected columns: %v", cols)
+	}
+	if q.FromTable() != "users" {
+		t.Errorf("unexpected from: %s", q.FromTable())
+	}
+	if joins := q.Joins(); len(joins) != 1 || joins[0].Kind != "LEFT JOIN" {
+		t.Errorf("unexpected joins: %+v", joins)
+	}
+	if wheres := q.Wheres(); len(wheres) != 1 || wheres[0].Tag != "visible" {
+		t.Errorf("unexpected wheres: %+v", wheres)
+	}
+	if orderBys := q.OrderBys(); len(orderBys) != 1 || orderBys[0].SQL != "name" {
+		t.Errorf("unexpected order bys: %+v", orderBys)
+	}
+	if n, ok := q.LimitValue(); !ok || n != 5 {
+		t.Errorf("unexpected limit: %d, %v", n, ok)
+	}
+}
+
+func TestTypedSelect_AsSubquery(t *testing.T) {
+	sub := NewTypedSelect[typedUser]().From("users").Where("active = :active", Params{"active": true})
+
+	q, _, err := New().
+		Select("*").
+		From("orders").
+		WhereIn("user_id", sub.RemoveSelect("name").Builder()).
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "SELECT * FROM orders WHERE user_id IN (SELECT id FROM users WHERE active = :active)"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+}
+
+func TestTypedSelect_Naming(t *testing.T) {
+	type account struct {
+		AccountID int
+		PlanName  string
+	}
+
+	q := NewTypedSelect[account]().From("accounts").Naming(CamelCase)
+
+	query, _, err := q.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "SELECT accountID, planName FROM accounts"
+	if query != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", query, expected)
+	}
+
+	db, _ := openFakeDB(t, []string{"accountID", "planName"}, []driver.Value{int64(7), "pro"})
+
+	a, err := q.One(context.Background(), db)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a.AccountID != 7 || a.PlanName != "pro" {
+		t.Errorf("unexpected account: %+v", a)
+	}
+}
+
+func TestTypedSelect_NamingChangedAfterConstruction(t *testing.T) {
+	type account struct {
+		AccountID int
+	}
+
+	q := NewTypedSelect[account]().From("accounts")
+	SetNamingStrategy(CamelCase)
+	t.Cleanup(func() { SetNamingStrategy(nil) })
+
+	db, _ := openFakeDB(t, []string{"account_id"}, []driver.Value{int64(7)})
+
+	a, err := q.One(context.Background(), db)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a.AccountID != 7 {
+		t.Errorf("unexpected account: %+v", a)
+	}
+}
@@ -0,0 +1,9 @@
+package squildx
+
+// All marks the UPDATE as intentionally affecting every row, bypassing the
+// ErrUpdateNoWhere guard. Combining All with a WHERE clause fails at Build.
+func (b *updateBuilder) All() UpdateBuilder {
+	cp := b.clone()
+	cp.all = true
+	return cp
+}
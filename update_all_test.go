@@ -0,0 +1,65 @@
+package squildx
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestUpdateAll(t *testing.T) {
+	q, params, err := NewUpdate().
+		Table("users").
+		Set("active = :active", Params{"active": false}).
+		All().
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "UPDATE users SET active = :active"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+	assertParam(t, params, "active", false)
+}
+
+func TestUpdateAll_WithReturning(t *testing.T) {
+	q, _, err := NewUpdate().
+		Table("users").
+		Set("active = FALSE").
+		All().
+		Returning("id").
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "UPDATE users SET active = FALSE RETURNING id"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+}
+
+func TestUpdateAll_WithWhere(t *testing.T) {
+	_, _, err := NewUpdate().
+		Table("users").
+		Set("active = FALSE").
+		All().
+		Where("id = :id", Params{"id": 1}).
+		Build()
+
+	if !errors.Is(err, ErrUpdateAllWhere) {
+		t.Errorf("expected ErrUpdateAllWhere, got: %v", err)
+	}
+}
+
+func TestUpdateAll_Immutability(t *testing.T) {
+	base := NewUpdate().Table("users").Set("active = FALSE")
+	_ = base.All()
+
+	_, _, err := base.Build()
+	if !errors.Is(err, ErrUpdateNoWhere) {
+		t.Errorf("expected base to still require WHERE, got: %v", err)
+	}
+}
@@ -6,74 +6,72 @@ import (
 )
 
 func (b *updateBuilder) Build() (string, Params, error) {
-	if b.err != nil {
-		return "", nil, b.err
-	}
+	r := newRenderer(b.paramPrefix, b.errs)
+	r.scopes = b.scoping.active(nil)
+	r.renamed = b.renamed
 
 	if b.table == "" {
-		return "", nil, ErrUpdateNoTable
+		r.fail(ErrUpdateNoTable)
 	}
 	if len(b.sets) == 0 {
-		return "", nil, ErrUpdateNoSet
+		r.fail(ErrUpdateNoSet)
 	}
-	if len(b.wheres) == 0 {
-		return "", nil, ErrUpdateNoWhere
+	switch {
+	case b.all && len(b.wheres) > 0:
+		r.fail(ErrUpdateAllWhere)
+	case !b.all && len(b.wheres) == 0:
+		r.fail(ErrUpdateNoWhere)
 	}
 
-	params := make(Params)
-	prefix := b.paramPrefix
-
 	var sb strings.Builder
 
 	sb.WriteString("UPDATE ")
 	sb.WriteString(b.table)
 
+	// Render WHERE first so that the placeholders of SetValue and audit
+	// columns follow the prefix of the rest of the query.
+	scopeConds := r.scopeConditions("Table", 0, b.table)
+	var where string
+	if len(b.wheres) > 0 || len(scopeConds) > 0 {
+		where = scopedWhere(r.wheres(b.wheres), scopeConds)
+	}
+
 	setClauses := make([]string, len(b.sets))
+	assigned := make(map[string]bool)
 	for i, s := range b.sets {
-		setPrefix := detectPrefix(s.sql)
-		var err error
-		prefix, err = reconcilePrefix(prefix, setPrefix)
-		if err != nil {
-			return "", nil, err
+		sql := s.sql
+		if s.bind != "" {
+			sql += string(r.bindPrefix()) + s.bind
+		}
+		// PostgreSQL rejects a column assigned twice, e.g. by SetObject and SetValue.
+		for _, col := range assignedColumns(b.sets[i : i+1]) {
+			if assigned[normalizeTable(col)] {
+				r.failClause(s.method, s.index, sql, fmt.Errorf("%w: %s", ErrDuplicateSetColumn, col))
+			}
+			assigned[normalizeTable(col)] = true
 		}
-		setClauses[i] = s.sql
-		if err := mergeParams(params, s.params); err != nil {
-			return "", nil, err
+		r.reconcile(s.method, s.index, sql)
+		setClauses[i] = sql
+		r.merge(s.method, s.index, sql, s.params)
+	}
+	if len(b.sets) > 0 {
+		p := resolveAudit(b.audit)
+		for _, a := range p.values(r, []string{p.UpdatedAt}, []string{p.UpdatedBy}, assignedColumns(b.sets)) {
+			setClauses = append(setClauses, a.column+" = "+a.expr)
 		}
 	}
 	sb.WriteString(" SET ")
 	sb.WriteString(strings.Join(setClauses, ", "))
 
-	ands := make([]string, len(b.wheres))
-	for i, w := range b.wheres {
-		if w.subQuery != nil {
-			subSQL, subParams, err := w.subQuery.Build()
-			if err != nil {
-				return "", nil, err
-			}
-			subPrefix := detectPrefix(subSQL)
-			prefix, err = reconcilePrefix(prefix, subPrefix)
-			if err != nil {
-				return "", nil, err
-			}
-			ands[i] = fmt.Sprintf("%s (%s)", w.subPrefix, subSQL)
-			if err := mergeParams(params, subParams); err != nil {
-				return "", nil, err
-			}
-			continue
-		}
-		ands[i] = w.sql
-		if err := mergeParams(params, w.params); err != nil {
-			return "", nil, err
-		}
+	if where != "" {
+		sb.WriteString(" WHERE ")
+		sb.WriteString(where)
 	}
-	sb.WriteString(" WHERE ")
-	sb.WriteString(strings.Join(ands, " AND "))
 
 	if len(b.returnings) > 0 {
 		sb.WriteString(" RETURNING ")
 		sb.WriteString(strings.Join(b.returnings, ", "))
 	}
 
-	return sb.String(), params, nil
+	return r.result(sb.String())
 }
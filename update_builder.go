@@ -1,18 +1,37 @@
 package squildx
 
+import "maps"
+
 // UpdateBuilder provides a fluent, immutable API for constructing UPDATE queries.
 type UpdateBuilder interface {
 	Table(table string) UpdateBuilder
 	Set(sql string, params ...Params) UpdateBuilder
 	SetObject(obj any) UpdateBuilder
+	SetObjectFields(obj any, columns ...string) UpdateBuilder
+	SetObjectExcept(obj any, columns ...string) UpdateBuilder
+	SetValue(column string, value any) UpdateBuilder
+	SetMap(values map[string]any) UpdateBuilder
+	SetExpr(column, sql string, params ...Params) UpdateBuilder
+	Increment(column string, delta any) UpdateBuilder
 	Where(sql string, params ...Params) UpdateBuilder
+	WhereTagged(tag, sql string, params ...Params) UpdateBuilder
+	RemoveWhere(tag string) UpdateBuilder
 	WhereExists(sub Builder) UpdateBuilder
 	WhereNotExists(sub Builder) UpdateBuilder
 	WhereIn(column string, sub Builder) UpdateBuilder
 	WhereNotIn(column string, sub Builder) UpdateBuilder
+	All() UpdateBuilder
 	Returning(columns ...string) UpdateBuilder
 	ReturningObject(obj any) UpdateBuilder
+	Naming(ns NamingStrategy) UpdateBuilder
+	Audit(p AuditPolicy) UpdateBuilder
+	Scoped(scopes ...Scope) UpdateBuilder
+	Unscoped(names ...string) UpdateBuilder
+	TableName() string
+	Wheres() []Clause
 	Build() (string, Params, error)
+	BuildArgs() (string, []any, error)
+	Compile() (*Template, error)
 }
 
 type updateBuilder struct {
@@ -20,8 +39,14 @@ type updateBuilder struct {
 	sets        []paramClause
 	wheres      []paramClause
 	returnings  []string
+	all         bool
+	naming      NamingStrategy    // nil = global strategy
+	audit       *AuditPolicy      // nil = global policy
+	scoping     scoping           // Scoped and Unscoped calls
+	renamed     map[string]string // renamed table -> original name, see RewriteTables
 	paramPrefix byte
-	err         error
+	failed      map[string]int // failed calls per clause kind, see failAdd
+	errs        []error
 }
 
 func NewUpdate() UpdateBuilder {
@@ -33,9 +58,39 @@ func (b *updateBuilder) clone() *updateBuilder {
 	cp.sets = copySlice(b.sets)
 	cp.wheres = copySlice(b.wheres)
 	cp.returnings = copySlice(b.returnings)
+	cp.scoping = b.scoping.clone()
+	cp.renamed = maps.Clone(b.renamed)
+	cp.failed = maps.Clone(b.failed)
+	cp.errs = copySlice(b.errs)
 	return &cp
 }
 
 func (b *updateBuilder) setPrefix(prefix byte) error {
 	return checkSetPrefix(&b.paramPrefix, prefix)
 }
+
+// resetPrefix recomputes paramPrefix from the remaining clauses, as
+// builder.resetPrefix does.
+func (b *updateBuilder) resetPrefix() {
+	b.paramPrefix = clausesPrefix(b.sets, b.wheres)
+}
+
+func (b *updateBuilder) fail(method string, index int, sql string, err error) {
+	b.errs = append(b.errs, &BuildError{Method: method, Index: index, SQL: sql, Err: err})
+}
+
+// callIndex returns the position of a call adding a clause of kind, as
+// builder.callIndex does.
+func (b *updateBuilder) callIndex(kind string, n int) int {
+	return n + b.failed[kind]
+}
+
+// failAdd records err for a call that failed to add a clause of kind, as
+// builder.failAdd does.
+func (b *updateBuilder) failAdd(kind, method string, n int, sql string, err error) {
+	b.fail(method, b.callIndex(kind, n), sql, err)
+	if b.failed == nil {
+		b.failed = make(map[string]int)
+	}
+	b.failed[kind]++
+}
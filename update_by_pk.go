@@ -0,0 +1,18 @@
+package squildx
+
+// UpdateByPK returns an UPDATE of table that assigns every field of obj, as
+// SetObject does, and matches the row by the fields tagged pk:
+//
+//	UpdateByPK("users", u) // UPDATE users SET name = :name, ... WHERE id = :id
+//
+// Composite keys add one WHERE clause per pk field. Building fails with
+// ErrNoPrimaryKey when obj has no pk field or one of them is a nil pointer.
+// Untagged fields are named by the global NamingStrategy.
+func UpdateByPK(table string, obj any) UpdateBuilder {
+	b := &updateBuilder{table: table}
+	if _, err := structKeys(obj, b.naming); err != nil {
+		b.fail("UpdateByPK", 0, "", err)
+		return b
+	}
+	return b.SetObject(obj)
+}
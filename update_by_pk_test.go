@@ -0,0 +1,89 @@
+package squildx
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestUpdateByPK(t *testing.T) {
+	type User struct {
+		ID    int    `squildx:"id,pk"`
+		Name  string `db:"name"`
+		Email string `db:"email"`
+	}
+
+	q, params, err := UpdateByPK("users", User{ID: 1, Name: "Alice", Email: "a@example.com"}).Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "UPDATE users SET name = :name, email = :email WHERE id = :id"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+	assertParam(t, params, "id", 1)
+	assertParam(t, params, "name", "Alice")
+	assertParam(t, params, "email", "a@example.com")
+}
+
+func TestUpdateByPK_CompositeKey(t *testing.T) {
+	type Membership struct {
+		OrgID  int    `squildx:"org_id,pk"`
+		UserID int    `squildx:"user_id,pk"`
+		Role   string `db:"role"`
+	}
+
+	q, params, err := UpdateByPK("memberships", &Membership{OrgID: 7, UserID: 3, Role: "admin"}).
+		Returning("role").
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "UPDATE memberships SET role = :role WHERE org_id = :org_id AND user_id = :user_id RETURNING role"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+	assertParam(t, params, "org_id", 7)
+	assertParam(t, params, "user_id", 3)
+}
+
+func TestUpdateByPK_NoPrimaryKey(t *testing.T) {
+	type User struct {
+		ID   int    `db:"id"`
+		Name string `db:"name"`
+	}
+
+	_, _, err := UpdateByPK("users", User{ID: 1, Name: "Alice"}).Build()
+
+	if !errors.Is(err, ErrNoPrimaryKey) {
+		t.Errorf("expected ErrNoPrimaryKey, got: %v", err)
+	}
+	var be *BuildError
+	if !errors.As(err, &be) || be.Method != "UpdateByPK" {
+		t.Errorf("expected UpdateByPK BuildError, got: %v", err)
+	}
+}
+
+func TestUpdateByPK_NilKey(t *testing.T) {
+	type User struct {
+		ID   *int   `squildx:"id,pk"`
+		Name string `db:"name"`
+	}
+
+	_, _, err := UpdateByPK("users", User{Name: "Alice"}).Build()
+
+	if !errors.Is(err, ErrNoPrimaryKey) {
+		t.Errorf("expected ErrNoPrimaryKey, got: %v", err)
+	}
+}
+
+func TestUpdateByPK_NotAStruct(t *testing.T) {
+	_, _, err := UpdateByPK("users", 42).Build()
+
+	if !errors.Is(err, ErrNotAStruct) {
+		t.Errorf("expected ErrNotAStruct, got: %v", err)
+	}
+}
@@ -8,9 +8,9 @@ func (b *updateBuilder) Returning(columns ...string) UpdateBuilder {
 
 func (b *updateBuilder) ReturningObject(obj any) UpdateBuilder {
 	cp := b.clone()
-	cols, err := structColumns(obj, "")
+	cols, err := structColumns(obj, "", optNested, cp.naming)
 	if err != nil {
-		cp.err = err
+		cp.failAdd("returnings", "ReturningObject", len(cp.returnings), "", err)
 		return cp
 	}
 	cp.returnings = append(cp.returnings, cols...)
@@ -1,108 +1,154 @@
 package squildx
 
 import (
+	"fmt"
 	"reflect"
+	"slices"
 	"strings"
 )
 
 func (b *updateBuilder) Set(sql string, params ...Params) UpdateBuilder {
+	return b.set("Set", sql, params)
+}
+
+func (b *updateBuilder) set(method, sql string, params []Params) *updateBuilder {
 	cp := b.clone()
 	extracted, err := extractParams(params)
 	if err != nil {
-		cp.err = err
+		cp.failAdd("sets", method, len(cp.sets), sql, err)
 		return cp
 	}
 	parsed, prefix, err := parseParams(sql, extracted)
 	if err != nil {
-		cp.err = err
+		cp.failAdd("sets", method, len(cp.sets), sql, err)
 		return cp
 	}
 	if err := cp.setPrefix(prefix); err != nil {
-		cp.err = err
+		cp.failAdd("sets", method, len(cp.sets), sql, err)
 		return cp
 	}
-	cp.sets = append(cp.sets, paramClause{sql: sql, params: parsed})
+	cp.sets = append(cp.sets, paramClause{
+		sql:    sql,
+		params: parsed,
+		method: method,
+		index:  cp.callIndex("sets", len(cp.sets)),
+	})
 	return cp
 }
 
+// SetObject assigns every field of obj. Nil pointers are skipped, as are
+// fields tagged readonly or immutable. Fields tagged pk are not assigned but
+// added to the WHERE clause instead. A field tagged version is incremented
+// and its current value added to the WHERE clause; see CheckVersion.
 func (b *updateBuilder) SetObject(obj any) UpdateBuilder {
+	return b.setObject("SetObject", obj, fieldMask{})
+}
+
+// SetObjectFields assigns only the named columns of obj, for PATCH-style
+// updates. Listed nil pointers are written as NULL. Fields tagged pk are added
+// to the WHERE clause as with SetObject. Naming a column that is not a
+// settable field of obj fails with ErrUnknownField.
+func (b *updateBuilder) SetObjectFields(obj any, columns ...string) UpdateBuilder {
+	return b.setObject("SetObjectFields", obj, fieldMask{only: true, columns: columns})
+}
+
+// SetObjectExcept is like SetObject but leaves out the named columns. Naming a
+// column that is not a settable field of obj fails with ErrUnknownField.
+func (b *updateBuilder) SetObjectExcept(obj any, columns ...string) UpdateBuilder {
+	return b.setObject("SetObjectExcept", obj, fieldMask{columns: columns})
+}
+
+func (b *updateBuilder) setObject(method string, obj any, mask fieldMask) *updateBuilder {
 	cp := b.clone()
-	sql, params, err := structSetSQL(obj)
+	sql, params, keys, err := structSetSQL(obj, cp.naming, mask)
 	if err != nil {
-		cp.err = err
+		cp.failAdd("sets", method, len(cp.sets), sql, err)
 		return cp
 	}
-	if sql == "" {
+	if sql == "" && len(keys) == 0 {
 		return cp
 	}
-	if err := cp.setPrefix(detectPrefix(sql)); err != nil {
-		cp.err = err
+	if err := cp.setPrefix(':'); err != nil {
+		cp.failAdd("sets", method, len(cp.sets), sql, err)
 		return cp
 	}
-	cp.sets = append(cp.sets, paramClause{sql: sql, params: params})
+	index := cp.callIndex("sets", len(cp.sets))
+	if sql != "" {
+		cp.sets = append(cp.sets, paramClause{sql: sql, params: params, method: method, index: index})
+	}
+	for _, k := range keys {
+		k.method, k.index = method, index
+		cp.wheres = append(cp.wheres, k)
+	}
 	return cp
 }
 
-func structSetSQL(obj any) (string, Params, error) {
-	v := reflect.ValueOf(obj)
-	t := reflect.TypeOf(obj)
-	if t == nil {
-		return "", nil, ErrNotAStruct
+// fieldMask restricts the columns assigned by structSetSQL: with only set,
+// just the listed columns are assigned; otherwise the listed ones are skipped.
+type fieldMask struct {
+	only    bool
+	columns []string
+}
+
+// structSetSQL renders the SET assignments for obj, plus one WHERE clause per
+// primary key or version field. A nil version field fails with ErrNilVersion,
+// as the increment would otherwise run unguarded.
+func structSetSQL(obj any, ns NamingStrategy, mask fieldMask) (string, Params, []paramClause, error) {
+	v, err := structValue(obj)
+	if err != nil {
+		return "", nil, nil, err
 	}
-	for t.Kind() == reflect.Ptr {
-		t = t.Elem()
-		v = v.Elem()
+
+	fields := structFields(v.Type(), ns)
+	listed := make(map[string]bool, len(mask.columns))
+	for _, col := range mask.columns {
+		listed[col] = true
 	}
-	if t.Kind() != reflect.Struct {
-		return "", nil, ErrNotAStruct
+	for _, col := range mask.columns {
+		settable := slices.ContainsFunc(fields, func(f structField) bool {
+			return f.name == col && !f.opts.has(optPK|optVersion|optReadonly|optImmutable|optNested)
+		})
+		if !settable {
+			return "", nil, nil, fmt.Errorf("%w: %q", ErrUnknownField, col)
+		}
 	}
 
 	var assignments []string
+	var keys []paramClause
 	params := Params{}
-	collectSetClauses(t, v, &assignments, params)
-	return strings.Join(assignments, ", "), params, nil
-}
-
-func collectSetClauses(t reflect.Type, v reflect.Value, assignments *[]string, params Params) {
-	for i := range t.NumField() {
-		f := t.Field(i)
-		if !f.IsExported() {
+	for _, f := range fields {
+		if f.opts.has(optReadonly | optImmutable | optNested) {
 			continue
 		}
-
-		ft := f.Type
-		fv := v.Field(i)
-
-		tagName := fieldTagName(f)
-		if tagName == "-" {
+		if !f.opts.has(optPK|optVersion) && listed[f.name] != mask.only {
 			continue
 		}
-
-		if f.Anonymous && ft.Kind() == reflect.Ptr {
-			if fv.IsNil() {
-				continue
-			}
-			ft = ft.Elem()
-			fv = fv.Elem()
-		}
-
-		if f.Anonymous && ft.Kind() == reflect.Struct && tagName == "" {
-			collectSetClauses(ft, fv, assignments, params)
-			continue
+		fv, ok := f.value(v)
+		isNil := !ok || (fv.Kind() == reflect.Ptr && fv.IsNil())
+		if f.opts.has(optVersion) && isNil {
+			return "", nil, nil, fmt.Errorf("%w: %s is nil", ErrNilVersion, f.name)
 		}
-
-		if ft.Kind() == reflect.Ptr {
-			if fv.IsNil() {
-				continue
+		if f.opts.has(optPK | optVersion) {
+			if !isNil {
+				keys = append(keys, paramClause{
+					sql:    f.assignment,
+					params: Params{f.name: indirect(fv)},
+				})
+			}
+			if f.opts.has(optVersion) {
+				assignments = append(assignments, f.name+" = "+f.name+" + 1")
 			}
-			fv = fv.Elem()
+			continue
 		}
-
-		name := tagName
-		if name == "" {
-			name = toSnakeCase(f.Name)
+		switch {
+		case isNil && !mask.only:
+			continue
+		case isNil:
+			params[f.name] = nil
+		default:
+			params[f.name] = indirect(fv)
 		}
-		*assignments = append(*assignments, name+" = :"+name)
-		params[name] = fv.Interface()
+		assignments = append(assignments, f.assignment)
 	}
+	return strings.Join(assignments, ", "), params, keys, nil
 }
@@ -1,6 +1,7 @@
 package squildx
 
 import (
+	"database/sql"
 	"errors"
 	"testing"
 )
@@ -294,3 +295,190 @@ func TestUpdateSetObject_SkipTaggedDash(t *testing.T) {
 		t.Errorf("expected 2 params, got %d", len(params))
 	}
 }
+
+func TestUpdateSetObject_PrimaryKeyWhere(t *testing.T) {
+	type User struct {
+		TenantID int    `squildx:"tenant_id,pk"`
+		ID       int    `squildx:"id,pk"`
+		Name     string `db:"name"`
+	}
+
+	q, params, err := NewUpdate().
+		Table("users").
+		SetObject(User{TenantID: 3, ID: 1, Name: "Alice"}).
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "UPDATE users SET name = :name WHERE tenant_id = :tenant_id AND id = :id"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+	assertParam(t, params, "tenant_id", 3)
+	assertParam(t, params, "id", 1)
+	assertParam(t, params, "name", "Alice")
+}
+
+func TestUpdateSetObject_ReadonlyAndImmutableSkipped(t *testing.T) {
+	type User struct {
+		Name      string `db:"name"`
+		Search    string `squildx:"search,readonly"`
+		CreatedAt string `squildx:"created_at,immutable"`
+		Nickname  string `squildx:"nickname,omitinsert"`
+	}
+
+	q, params, err := NewUpdate().
+		Table("users").
+		SetObject(User{Name: "Alice", Search: "x", CreatedAt: "2024-01-01", Nickname: "Al"}).
+		Where("id = :id", Params{"id": 1}).
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "UPDATE users SET name = :name, nickname = :nickname WHERE id = :id"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+	if len(params) != 3 {
+		t.Errorf("expected 3 params, got %d: %v", len(params), params)
+	}
+}
+
+func TestUpdateSetObject_NilEmbeddedPointerSkipped(t *testing.T) {
+	type Timestamps struct {
+		UpdatedAt string `db:"updated_at"`
+	}
+	type UserPatch struct {
+		*Timestamps
+		Name string `db:"name"`
+	}
+
+	q, _, err := NewUpdate().
+		Table("users").
+		SetObject(UserPatch{Name: "Alice"}).
+		Where("id = :id", Params{"id": 1}).
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "UPDATE users SET name = :name WHERE id = :id"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+}
+
+func TestUpdateSetObjectFields(t *testing.T) {
+	type User struct {
+		ID    int     `squildx:"id,pk"`
+		Name  string  `db:"name"`
+		Email *string `db:"email"`
+		Age   int     `db:"age"`
+	}
+
+	q, params, err := NewUpdate().
+		Table("users").
+		SetObjectFields(User{ID: 1, Name: "Alice", Age: 0}, "email", "age").
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "UPDATE users SET email = :email, age = :age WHERE id = :id"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+	assertParam(t, params, "id", 1)
+	assertParam(t, params, "email", nil)
+	assertParam(t, params, "age", 0)
+	if _, ok := params["name"]; ok {
+		t.Errorf("unexpected param name: %v", params)
+	}
+}
+
+func TestUpdateSetObjectFields_NullType(t *testing.T) {
+	type User struct {
+		Nickname sql.NullString `db:"nickname"`
+	}
+
+	q, params, err := NewUpdate().
+		Table("users").
+		SetObjectFields(User{}, "nickname").
+		Where("id = :id", Params{"id": 1}).
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "UPDATE users SET nickname = :nickname WHERE id = :id"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+	assertParam(t, params, "nickname", sql.NullString{})
+}
+
+func TestUpdateSetObjectFields_UnknownField(t *testing.T) {
+	type User struct {
+		ID     int    `squildx:"id,pk"`
+		Name   string `db:"name"`
+		Search string `squildx:"search,readonly"`
+	}
+
+	for _, field := range []string{"nme", "search", "id"} {
+		_, _, err := NewUpdate().
+			Table("users").
+			SetObjectFields(User{ID: 1}, field).
+			Build()
+
+		if !errors.Is(err, ErrUnknownField) {
+			t.Errorf("%s: expected ErrUnknownField, got: %v", field, err)
+		}
+		var be *BuildError
+		if !errors.As(err, &be) || be.Method != "SetObjectFields" {
+			t.Errorf("%s: expected SetObjectFields BuildError, got: %v", field, err)
+		}
+	}
+}
+
+func TestUpdateSetObjectExcept(t *testing.T) {
+	type User struct {
+		ID       int     `squildx:"id,pk"`
+		Name     string  `db:"name"`
+		Email    string  `db:"email"`
+		Nickname *string `db:"nickname"`
+	}
+
+	q, params, err := NewUpdate().
+		Table("users").
+		SetObjectExcept(User{ID: 1, Name: "Alice", Email: "a@example.com"}, "email").
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "UPDATE users SET name = :name WHERE id = :id"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+	assertParam(t, params, "id", 1)
+	assertParam(t, params, "name", "Alice")
+	if len(params) != 2 {
+		t.Errorf("expected 2 params, got %d: %v", len(params), params)
+	}
+
+	_, _, err = NewUpdate().
+		Table("users").
+		SetObjectExcept(User{ID: 1}, "mail").
+		Build()
+	if !errors.Is(err, ErrUnknownField) {
+		t.Errorf("expected ErrUnknownField, got: %v", err)
+	}
+}
@@ -0,0 +1,70 @@
+package squildx
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// SetValue assigns value to column. The parameter is named set_<column>_<n>,
+// where n is the position of the assignment, so it cannot collide with the
+// parameters of other SET clauses, including those of SetObject.
+func (b *updateBuilder) SetValue(column string, value any) UpdateBuilder {
+	cp := b.clone()
+	cp.setValue("SetValue", column, "", value)
+	return cp
+}
+
+// SetMap assigns each value to its column, in column name order so the
+// generated SQL is deterministic. Parameters are named as by SetValue.
+func (b *updateBuilder) SetMap(values map[string]any) UpdateBuilder {
+	cp := b.clone()
+	for _, column := range slices.Sorted(maps.Keys(values)) {
+		cp.setValue("SetMap", column, "", values[column])
+	}
+	return cp
+}
+
+// SetExpr assigns the SQL expression to column: SetExpr("tags", "array_append(tags, :tag)", ...)
+// is shorthand for Set("tags = array_append(tags, :tag)", ...).
+func (b *updateBuilder) SetExpr(column, sql string, params ...Params) UpdateBuilder {
+	return b.set("SetExpr", column+" = "+sql, params)
+}
+
+// Increment adds delta to column. Use a negative delta to decrement.
+func (b *updateBuilder) Increment(column string, delta any) UpdateBuilder {
+	cp := b.clone()
+	cp.setValue("Increment", column, column+" + ", delta)
+	return cp
+}
+
+// setValue appends "column = <expr><placeholder>" with a generated parameter
+// name. The placeholder prefix is that of the rest of the query, chosen at
+// build time.
+func (b *updateBuilder) setValue(method, column, expr string, value any) {
+	name := setParamName(column, len(b.sets))
+	b.sets = append(b.sets, paramClause{
+		sql:    column + " = " + expr,
+		params: Params{name: value},
+		bind:   name,
+		method: method,
+		index:  b.callIndex("sets", len(b.sets)),
+	})
+}
+
+// setParamName returns set_<column>_<index>.
+func setParamName(column string, index int) string {
+	return "set_" + sanitizeParamName(column) + "_" + strconv.Itoa(index)
+}
+
+// sanitizeParamName replaces the characters of s that cannot appear in a
+// placeholder name, such as the dot of a qualified column, with underscores.
+func sanitizeParamName(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '_'
+	}, s)
+}
@@ -0,0 +1,229 @@
+package squildx
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestUpdateSetValue(t *testing.T) {
+	q, params, err := NewUpdate().
+		Table("users").
+		SetValue("name", "Alice").
+		SetValue("u.email", "a@example.com").
+		Where("id = :id", Params{"id": 1}).
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "UPDATE users SET name = :set_name_0, u.email = :set_u_email_1 WHERE id = :id"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+	assertParam(t, params, "set_name_0", "Alice")
+	assertParam(t, params, "set_u_email_1", "a@example.com")
+}
+
+func TestUpdateSetValue_SameColumnTwice(t *testing.T) {
+	q, params, err := NewUpdate().
+		Table("users").
+		SetValue("name", "Alice").
+		SetValue("name", "Bob").
+		All().
+		Build()
+
+	var be *BuildError
+	if !errors.As(err, &be) || be.Method != "SetValue" || be.Index != 1 {
+		t.Errorf("expected SetValue #1 BuildError, got: %v", err)
+	}
+	if !errors.Is(err, ErrDuplicateSetColumn) {
+		t.Errorf("expected ErrDuplicateSetColumn, got: %v", err)
+	}
+	if q != "" || params != nil {
+		t.Errorf("expected no SQL on error, got: %s", q)
+	}
+}
+
+func TestUpdateSetValue_WithSetObject(t *testing.T) {
+	type User struct {
+		ID   int    `squildx:"id,pk"`
+		Name string `db:"name"`
+	}
+
+	_, _, err := NewUpdate().
+		Table("users").
+		SetObject(User{ID: 1, Name: "Alice"}).
+		SetValue("Name", "Bob").
+		Build()
+
+	if !errors.Is(err, ErrDuplicateSetColumn) {
+		t.Errorf("expected ErrDuplicateSetColumn, got: %v", err)
+	}
+
+	q, params, err := NewUpdate().
+		Table("users").
+		SetObject(User{ID: 1, Name: "Alice"}).
+		SetValue("email", "alice@example.com").
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "UPDATE users SET name = :name, email = :set_email_1 WHERE id = :id"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+	assertParam(t, params, "name", "Alice")
+	assertParam(t, params, "set_email_1", "alice@example.com")
+}
+
+func TestUpdateSetValue_AtPrefix(t *testing.T) {
+	q, _, err := NewUpdate().
+		Table("users").
+		Set("active = @active", Params{"active": true}).
+		SetValue("name", "Alice").
+		Where("id = @id", Params{"id": 1}).
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "UPDATE users SET active = @active, name = @set_name_1 WHERE id = @id"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+}
+
+func TestUpdateSetValue_PrefixChosenAtBuild(t *testing.T) {
+	q, params, err := NewUpdate().
+		Table("users").
+		SetValue("name", "Alice").
+		Increment("logins", 1).
+		Where("id = @id", Params{"id": 1}).
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "UPDATE users SET name = @set_name_0, logins = logins + @set_logins_1 WHERE id = @id"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+	assertParam(t, params, "set_name_0", "Alice")
+
+	q, _, err = NewUpdate().Table("users").SetValue("name", "Alice").All().Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected = "UPDATE users SET name = :set_name_0"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+}
+
+func TestUpdateSetMap(t *testing.T) {
+	q, params, err := NewUpdate().
+		Table("users").
+		SetMap(map[string]any{"name": "Alice", "age": 30, "email": nil}).
+		Where("id = :id", Params{"id": 1}).
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "UPDATE users SET age = :set_age_0, email = :set_email_1, name = :set_name_2 WHERE id = :id"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+	assertParam(t, params, "set_age_0", 30)
+	assertParam(t, params, "set_email_1", nil)
+	assertParam(t, params, "set_name_2", "Alice")
+}
+
+func TestUpdateSetMap_Empty(t *testing.T) {
+	_, _, err := NewUpdate().
+		Table("users").
+		SetMap(nil).
+		Where("id = :id", Params{"id": 1}).
+		Build()
+
+	if !errors.Is(err, ErrUpdateNoSet) {
+		t.Errorf("expected ErrUpdateNoSet, got: %v", err)
+	}
+}
+
+func TestUpdateSetExpr(t *testing.T) {
+	q, params, err := NewUpdate().
+		Table("posts").
+		SetExpr("tags", "array_append(tags, :tag)", Params{"tag": "go"}).
+		SetExpr("updated_at", "now()").
+		Where("id = :id", Params{"id": 1}).
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "UPDATE posts SET tags = array_append(tags, :tag), updated_at = now() WHERE id = :id"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+	assertParam(t, params, "tag", "go")
+}
+
+func TestUpdateSetExpr_MissingParam(t *testing.T) {
+	_, _, err := NewUpdate().
+		Table("posts").
+		SetExpr("tags", "array_append(tags, :tag)").
+		Where("id = :id", Params{"id": 1}).
+		Build()
+
+	var be *BuildError
+	if !errors.As(err, &be) || be.Method != "SetExpr" || be.Index != 0 {
+		t.Errorf("expected SetExpr #0 BuildError, got: %v", err)
+	}
+	if !errors.Is(err, ErrMissingParam) {
+		t.Errorf("expected ErrMissingParam, got: %v", err)
+	}
+}
+
+func TestUpdateIncrement(t *testing.T) {
+	q, params, err := NewUpdate().
+		Table("accounts").
+		Increment("balance", -50).
+		Increment("login_count", 1).
+		Where("id = :id", Params{"id": 1}).
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "UPDATE accounts SET balance = balance + :set_balance_0, login_count = login_count + :set_login_count_1 WHERE id = :id"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+	assertParam(t, params, "set_balance_0", -50)
+	assertParam(t, params, "set_login_count_1", 1)
+}
+
+func TestUpdateSetValue_Immutability(t *testing.T) {
+	base := NewUpdate().Table("users").Where("id = :id", Params{"id": 1})
+	a := base.SetValue("name", "Alice")
+	b := base.Increment("age", 1)
+
+	qa, _, _ := a.Build()
+	qb, _, _ := b.Build()
+
+	if qa != "UPDATE users SET name = :set_name_0 WHERE id = :id" {
+		t.Errorf("unexpected SQL for a: %s", qa)
+	}
+	if qb != "UPDATE users SET age = age + :set_age_0 WHERE id = :id" {
+		t.Errorf("unexpected SQL for b: %s", qb)
+	}
+}
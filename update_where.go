@@ -3,34 +3,69 @@ package squildx
 import "fmt"
 
 func (b *updateBuilder) Where(sql string, params ...Params) UpdateBuilder {
+	return b.where("Where", "", sql, params)
+}
+
+// WhereTagged adds a WHERE clause like Where, tagged so that RemoveWhere can
+// take it out again.
+func (b *updateBuilder) WhereTagged(tag, sql string, params ...Params) UpdateBuilder {
+	return b.where("WhereTagged", tag, sql, params)
+}
+
+func (b *updateBuilder) where(method, tag, sql string, params []Params) *updateBuilder {
 	cp := b.clone()
 	p, err := extractParams(params)
 	if err != nil {
-		cp.err = err
+		cp.failAdd("wheres", method, len(cp.wheres), sql, err)
 		return cp
 	}
 	parsed, prefix, err := parseParams(sql, p)
 	if err != nil {
-		cp.err = err
+		cp.failAdd("wheres", method, len(cp.wheres), sql, err)
 		return cp
 	}
 	if err := cp.setPrefix(prefix); err != nil {
-		cp.err = err
+		cp.failAdd("wheres", method, len(cp.wheres), sql, err)
 		return cp
 	}
-	cp.wheres = append(cp.wheres, paramClause{sql: sql, params: parsed})
+	cp.wheres = append(cp.wheres, paramClause{
+		sql:    sql,
+		params: parsed,
+		tag:    tag,
+		method: method,
+		index:  cp.callIndex("wheres", len(cp.wheres)),
+	})
+	return cp
+}
+
+// RemoveWhere removes the WHERE clauses added by WhereTagged with tag. An
+// empty tag removes nothing.
+func (b *updateBuilder) RemoveWhere(tag string) UpdateBuilder {
+	cp := b.clone()
+	cp.wheres = withoutTag(cp.wheres, tag)
+	cp.resetPrefix()
 	return cp
 }
 
 func (b *updateBuilder) WhereExists(sub Builder) UpdateBuilder {
 	cp := b.clone()
-	cp.wheres = append(cp.wheres, paramClause{subQuery: sub, subPrefix: "EXISTS"})
+	cp.wheres = append(cp.wheres, paramClause{
+		subQuery:  sub,
+		subPrefix: "EXISTS",
+		method:    "WhereExists",
+		index:     cp.callIndex("wheres", len(cp.wheres)),
+	})
 	return cp
 }
 
 func (b *updateBuilder) WhereNotExists(sub Builder) UpdateBuilder {
 	cp := b.clone()
-	cp.wheres = append(cp.wheres, paramClause{subQuery: sub, subPrefix: "NOT EXISTS"})
+	cp.wheres = append(cp.wheres, paramClause{
+		subQuery:  sub,
+		subPrefix: "NOT EXISTS",
+		method:    "WhereNotExists",
+		index:     cp.callIndex("wheres", len(cp.wheres)),
+	})
 	return cp
 }
 
@@ -39,6 +74,8 @@ func (b *updateBuilder) WhereIn(column string, sub Builder) UpdateBuilder {
 	cp.wheres = append(cp.wheres, paramClause{
 		subQuery:  sub,
 		subPrefix: fmt.Sprintf("%s IN", column),
+		method:    "WhereIn",
+		index:     cp.callIndex("wheres", len(cp.wheres)),
 	})
 	return cp
 }
@@ -48,6 +85,8 @@ func (b *updateBuilder) WhereNotIn(column string, sub Builder) UpdateBuilder {
 	cp.wheres = append(cp.wheres, paramClause{
 		subQuery:  sub,
 		subPrefix: fmt.Sprintf("%s NOT IN", column),
+		method:    "WhereNotIn",
+		index:     cp.callIndex("wheres", len(cp.wheres)),
 	})
 	return cp
 }
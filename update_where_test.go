@@ -157,3 +157,32 @@ func TestUpdateWhere_Immutability(t *testing.T) {
 		t.Errorf("expected 2 params, got %d", len(params))
 	}
 }
+
+func TestUpdateRemoveWhere(t *testing.T) {
+	base := NewUpdate().Table("users").
+		Set("name = :name", Params{"name": "Alice"}).
+		WhereTagged("tenant", "tenant_id = :tenant_id", Params{"tenant_id": 7}).
+		Where("id = :id", Params{"id": 1})
+
+	q, params, err := base.RemoveWhere("tenant").Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "UPDATE users SET name = :name WHERE id = :id"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+	if len(params) != 2 {
+		t.Errorf("expected 2 params, got %d", len(params))
+	}
+
+	_, _, err = NewUpdate().Table("users").
+		Set("name = :name", Params{"name": "Alice"}).
+		WhereTagged("pk", "id = :id", Params{"id": 1}).
+		RemoveWhere("pk").
+		Build()
+	if !errors.Is(err, ErrUpdateNoWhere) {
+		t.Errorf("expected ErrUpdateNoWhere, got: %v", err)
+	}
+}
@@ -0,0 +1,24 @@
+package squildx
+
+import "database/sql"
+
+// CheckVersion reports an optimistic locking conflict for an UPDATE built from
+// a struct with a version field. It passes err through, and otherwise returns
+// ErrVersionConflict when the statement affected no rows, meaning the row was
+// changed (or deleted) since it was read:
+//
+//	q, params, _ := squildx.UpdateByPK("users", u).Build()
+//	err := squildx.CheckVersion(db.NamedExecContext(ctx, q, params))
+func CheckVersion(res sql.Result, err error) error {
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrVersionConflict
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package squildx
+
+import (
+	"errors"
+	"testing"
+)
+
+type fakeResult struct {
+	rows int64
+	err  error
+}
+
+func (r fakeResult) LastInsertId() (int64, error) { return 0, nil }
+func (r fakeResult) RowsAffected() (int64, error) { return r.rows, r.err }
+
+func TestSetObject_Version(t *testing.T) {
+	type Doc struct {
+		ID      int    `squildx:"id,pk"`
+		Title   string `db:"title"`
+		Version int    `squildx:"version,version"`
+	}
+
+	q, params, err := NewUpdate().
+		Table("docs").
+		SetObject(Doc{ID: 1, Title: "Draft", Version: 4}).
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "UPDATE docs SET title = :title, version = version + 1 WHERE id = :id AND version = :version"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+	assertParam(t, params, "id", 1)
+	assertParam(t, params, "version", 4)
+}
+
+func TestUpdateByPK_Version(t *testing.T) {
+	type Doc struct {
+		ID       int    `squildx:"id,pk"`
+		Revision int64  `squildx:"revision,version"`
+		Title    string `db:"title"`
+	}
+
+	q, params, err := UpdateByPK("docs", &Doc{ID: 1, Revision: 2, Title: "Final"}).Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "UPDATE docs SET revision = revision + 1, title = :title WHERE id = :id AND revision = :revision"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+	assertParam(t, params, "revision", int64(2))
+}
+
+func TestSetObject_NilVersion(t *testing.T) {
+	type Doc struct {
+		ID      int    `squildx:"id,pk"`
+		Title   string `db:"title"`
+		Version *int   `squildx:"version,version"`
+	}
+
+	_, _, err := NewUpdate().
+		Table("docs").
+		SetObject(Doc{ID: 1, Title: "Draft"}).
+		Build()
+
+	if !errors.Is(err, ErrNilVersion) {
+		t.Errorf("expected ErrNilVersion, got: %v", err)
+	}
+
+	_, _, err = UpdateByPK("docs", Doc{ID: 1, Title: "Draft"}).Build()
+	if !errors.Is(err, ErrNilVersion) {
+		t.Errorf("expected ErrNilVersion from UpdateByPK, got: %v", err)
+	}
+
+	v := 4
+	q, _, err := NewUpdate().
+		Table("docs").
+		SetObject(Doc{ID: 1, Title: "Draft", Version: &v}).
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "UPDATE docs SET title = :title, version = version + 1 WHERE id = :id AND version = :version"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+}
+
+func TestSetObjectFields_Version(t *testing.T) {
+	type Doc struct {
+		ID      int    `squildx:"id,pk"`
+		Title   string `db:"title"`
+		Body    string `db:"body"`
+		Version int    `squildx:"version,version"`
+	}
+
+	q, _, err := NewUpdate().
+		Table("docs").
+		SetObjectFields(Doc{ID: 1, Title: "Draft", Version: 4}, "title").
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "UPDATE docs SET title = :title, version = version + 1 WHERE id = :id AND version = :version"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+
+	_, _, err = NewUpdate().
+		Table("docs").
+		SetObjectFields(Doc{ID: 1}, "version").
+		Build()
+	if !errors.Is(err, ErrUnknownField) {
+		t.Errorf("expected ErrUnknownField for the version column, got: %v", err)
+	}
+}
+
+func TestCheckVersion(t *testing.T) {
+	if err := CheckVersion(fakeResult{rows: 1}, nil); err != nil {
+		t.Errorf("expected nil, got: %v", err)
+	}
+	if err := CheckVersion(fakeResult{rows: 0}, nil); !errors.Is(err, ErrVersionConflict) {
+		t.Errorf("expected ErrVersionConflict, got: %v", err)
+	}
+
+	execErr := errors.New("connection reset")
+	if err := CheckVersion(nil, execErr); err != execErr {
+		t.Errorf("expected exec error to pass through, got: %v", err)
+	}
+
+	rowsErr := errors.New("rows affected not supported")
+	if err := CheckVersion(fakeResult{err: rowsErr}, nil); err != rowsErr {
+		t.Errorf("expected RowsAffected error to pass through, got: %v", err)
+	}
+}
@@ -0,0 +1,230 @@
+package squildx
+
+import "strings"
+
+// Query is implemented by every builder: Builder, InsertBuilder,
+// UpdateBuilder, DeleteBuilder and TruncateBuilder.
+type Query interface {
+	Build() (string, Params, error)
+}
+
+// Walk calls visit for each SELECT in q, depth first: q itself when it is a
+// Builder, then its subqueries. The subqueries of a SELECT are its lateral
+// joins before its WHERE subqueries, each in the order they were added; those
+// of an UPDATE or DELETE are its WHERE subqueries and that of an INSERT is its
+// SELECT. When visit returns false the subqueries of that query are skipped.
+func Walk(q Query, visit func(Builder) bool) {
+	if q == nil {
+		return
+	}
+	if b, ok := q.(Builder); ok && !visit(b) {
+		return
+	}
+	for _, sub := range subqueries(q) {
+		Walk(sub, visit)
+	}
+}
+
+// subqueries returns the subqueries nested directly in q, in Walk order.
+func subqueries(q Query) []Builder {
+	var subs []Builder
+	switch b := q.(type) {
+	case *builder:
+		for _, j := range b.joins {
+			if j.subQuery != nil {
+				subs = append(subs, j.subQuery)
+			}
+		}
+		subs = appendWhereSubqueries(subs, b.wheres)
+	case *updateBuilder:
+		subs = appendWhereSubqueries(subs, b.wheres)
+	case *deleteBuilder:
+		subs = appendWhereSubqueries(subs, b.wheres)
+	case *insertBuilder:
+		if b.selectQuery != nil {
+			subs = append(subs, b.selectQuery)
+		}
+	}
+	return subs
+}
+
+func appendWhereSubqueries(subs []Builder, wheres []paramClause) []Builder {
+	for _, w := range wheres {
+		if w.subQuery != nil {
+			subs = append(subs, w.subQuery)
+		}
+	}
+	return subs
+}
+
+// Rewrite returns a copy of q in which every SELECT of the tree is replaced
+// by the result of fn, innermost first, so fn sees a query with its
+// subqueries already rewritten. An INSERT, UPDATE or DELETE at the root is
+// copied with its subqueries rewritten, as fn only takes a Builder. q itself
+// is left unchanged:
+//
+//	unordered := squildx.Rewrite(q, func(b squildx.Builder) squildx.Builder {
+//		return b.ClearOrderBy()
+//	})
+func Rewrite[Q Query](q Q, fn func(Builder) Builder) Q {
+	var out Query
+	switch b := any(q).(type) {
+	case Builder:
+		out = rewriteBuilder(b, fn)
+	case *updateBuilder:
+		cp := b.clone()
+		rewriteWheres(cp.wheres, fn)
+		out = cp
+	case *deleteBuilder:
+		cp := b.clone()
+		rewriteWheres(cp.wheres, fn)
+		out = cp
+	case *insertBuilder:
+		cp := b.clone()
+		if cp.selectQuery != nil {
+			cp.selectQuery = rewriteBuilder(cp.selectQuery, fn)
+		}
+		out = cp
+	default:
+		return q
+	}
+	rewritten, _ := out.(Q)
+	return rewritten
+}
+
+func rewriteBuilder(q Builder, fn func(Builder) Builder) Builder {
+	if q == nil {
+		return nil
+	}
+	if b, ok := q.(*builder); ok {
+		cp := b.clone()
+		for i, j := range cp.joins {
+			if j.subQuery != nil {
+				cp.joins[i].subQuery = rewriteBuilder(j.subQuery, fn)
+			}
+		}
+		rewriteWheres(cp.wheres, fn)
+		q = cp
+	}
+	return fn(q)
+}
+
+// rewriteWheres rewrites the subqueries of wheres in place.
+func rewriteWheres(wheres []paramClause, fn func(Builder) Builder) {
+	for i, w := range wheres {
+		if w.subQuery != nil {
+			wheres[i].subQuery = rewriteBuilder(w.subQuery, fn)
+		}
+	}
+}
+
+// RewriteTables returns a copy of q with its tables, and those of every
+// subquery, renamed by fn, e.g. to add a schema or to pick a partition. The
+// tables of FROM and join clauses are renamed, as are the table of an UPDATE
+// or DELETE. A table referred to by its own name, such as "users", keeps
+// that name as an alias, so that columns qualified with it still resolve: fn
+// returning "users_2024" yields "users_2024 users". The table of an INSERT
+// and those of a TRUNCATE, which take no alias, are just renamed. Scopes and
+// RegisterSoftDelete keep matching the original table names.
+func RewriteTables[Q Query](q Q, fn func(table string) string) Q {
+	q = Rewrite(q, func(q Builder) Builder {
+		b, ok := q.(*builder)
+		if !ok {
+			return q
+		}
+		cp := b.clone()
+		cp.renamed = ensureMap(cp.renamed)
+		refs := splitTopLevel(cp.from, ',')
+		for i, ref := range refs {
+			refs[i] = renameTableRef(ref, fn, cp.renamed)
+		}
+		cp.from = strings.Join(refs, ",")
+		for i, j := range cp.joins {
+			if j.subQuery != nil {
+				continue
+			}
+			ref, _, _ := splitJoin(j.clause.sql)
+			at := strings.Index(j.clause.sql, ref)
+			cp.joins[i].clause.sql = j.clause.sql[:at] + renameTableRef(ref, fn, cp.renamed) + j.clause.sql[at+len(ref):]
+		}
+		return cp
+	})
+
+	// Rewrite has already copied an INSERT, UPDATE or DELETE root.
+	switch b := any(q).(type) {
+	case *updateBuilder:
+		b.renamed = ensureMap(b.renamed)
+		b.table = renameTableRef(b.table, fn, b.renamed)
+	case *deleteBuilder:
+		b.renamed = ensureMap(b.renamed)
+		b.table = renameTableRef(b.table, fn, b.renamed)
+	case *insertBuilder:
+		b.table = renameTable(b.table, fn)
+	case *truncateBuilder:
+		cp := b.clone()
+		for i, t := range cp.tables {
+			cp.tables[i] = renameTable(t, fn)
+		}
+		renamed, _ := any(cp).(Q)
+		return renamed
+	}
+	return q
+}
+
+// renameTable renames a table that cannot take an alias, such as the table
+// of an INSERT.
+func renameTable(table string, fn func(string) string) string {
+	name, _, ok := parseTableRef(table)
+	if !ok || len(strings.Fields(table)) != 1 {
+		return renameTableRef(table, fn, nil)
+	}
+	return strings.Replace(table, name, fn(name), 1)
+}
+
+// renameTableRef renames the table of a table reference such as "users u",
+// recording its original name in originals when that is not nil.
+func renameTableRef(ref string, fn func(string) string, originals map[string]string) string {
+	table, _, ok := parseTableRef(ref)
+	if !ok {
+		return ref
+	}
+	renamed := fn(table)
+	if renamed == table {
+		return ref
+	}
+	if originals != nil {
+		orig := table
+		if o, ok := originals[table]; ok {
+			orig = o // renamed before
+		}
+		originals[renamed] = orig
+	}
+	at := tableOffset(ref, table)
+	out := ref[:at] + renamed + ref[at+len(table):]
+	if fields, _ := tableRefFields(ref); len(fields) == 1 && bareTableName(renamed) != bareTableName(table) {
+		trail := ref[len(strings.TrimRight(ref, " \t\n")):]
+		out = strings.TrimSuffix(out, trail) + " " + bareTableName(table) + trail
+	}
+	return out
+}
+
+func ensureMap(m map[string]string) map[string]string {
+	if m == nil {
+		return make(map[string]string)
+	}
+	return m
+}
+
+// tableOffset returns the position of table in ref, after any leading ONLY.
+func tableOffset(ref, table string) int {
+	skip := len(ref) - len(strings.TrimLeft(ref, " \t\n\r"))
+	if keywordAt(ref, skip, "ONLY") {
+		skip += len("ONLY")
+	}
+	return skip + strings.Index(ref[skip:], table)
+}
+
+// bareTableName returns table without its schema.
+func bareTableName(table string) string {
+	return table[strings.LastIndexByte(table, '.')+1:]
+}
@@ -0,0 +1,270 @@
+package squildx
+
+import "testing"
+
+func TestWalk(t *testing.T) {
+	inner := New().Select("id").From("bans")
+	exists := New().Select("1").From("orders o").WhereIn("o.user_id", inner)
+	lateral := New().Select("total").From("payments p").Where("p.user_id = u.id")
+	q := New().Select("u.id").
+		From("users u").
+		LeftJoinLateral(lateral, "pay", "true").
+		WhereExists(exists)
+
+	var froms []string
+	Walk(q, func(b Builder) bool {
+		froms = append(froms, b.FromTable())
+		return true
+	})
+	want := []string{"users u", "payments p", "orders o", "bans"}
+	if len(froms) != len(want) {
+		t.Fatalf("visited %v, want %v", froms, want)
+	}
+	for i := range want {
+		if froms[i] != want[i] {
+			t.Errorf("visit #%d = %q, want %q", i, froms[i], want[i])
+		}
+	}
+
+	var count int
+	Walk(q, func(b Builder) bool {
+		count++
+		return b != exists
+	})
+	if count != 3 {
+		t.Errorf("expected the subqueries of exists to be skipped, visited %d", count)
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	sub := New().Select("user_id").From("orders").OrderBy("created_at")
+	q := New().Select("id").From("users").WhereIn("id", sub).OrderBy("name")
+
+	var seen []string
+	rewritten := Rewrite(q, func(b Builder) Builder {
+		seen = append(seen, b.FromTable())
+		if b.FromTable() == "orders" {
+			return b.ClearOrderBy()
+		}
+		return b
+	})
+
+	if len(seen) != 2 || seen[0] != "orders" || seen[1] != "users" {
+		t.Errorf("expected innermost first, got %v", seen)
+	}
+
+	got, _, err := rewritten.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "SELECT id FROM users WHERE id IN (SELECT user_id FROM orders) ORDER BY name"
+	if got != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", got, expected)
+	}
+
+	orig, _, err := q.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected = "SELECT id FROM users WHERE id IN (SELECT user_id FROM orders ORDER BY created_at) ORDER BY name"
+	if orig != expected {
+		t.Errorf("original was mutated\n got: %s\nwant: %s", orig, expected)
+	}
+}
+
+func TestRewriteTables(t *testing.T) {
+	sub := New().Select("user_id").From("orders o").Where("o.total > :min", Params{"min": 100})
+	lateral := New().Select("amount").From("payments").Where("payments.user_id = u.id")
+	q := New().Select("u.id", "users.name").
+		From("users u, public.teams").
+		LeftJoin("events AS e ON e.user_id = u.id").
+		CrossJoin("tags").
+		LeftJoinLateral(lateral, "pay", "true").
+		WhereIn("u.id", sub)
+
+	rewritten := RewriteTables(q, func(table string) string {
+		if table == "public.teams" {
+			return table
+		}
+		return "archive." + table + "_2024"
+	})
+
+	got, params, err := rewritten.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "SELECT u.id, users.name FROM archive.users_2024 u, public.teams" +
+		" LEFT JOIN archive.events_2024 AS e ON e.user_id = u.id" +
+		" CROSS JOIN archive.tags_2024 tags" +
+		" LEFT JOIN LATERAL (SELECT amount FROM archive.payments_2024 payments WHERE payments.user_id = u.id) pay ON true" +
+		" WHERE u.id IN (SELECT user_id FROM archive.orders_2024 o WHERE o.total > :min)"
+	if got != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", got, expected)
+	}
+	assertParam(t, params, "min", 100)
+}
+
+func TestRewriteTables_SchemaOnly(t *testing.T) {
+	q := RewriteTables(New().Select("users.id").From("users"), func(table string) string {
+		return "tenant_a." + table
+	})
+
+	got, _, err := q.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "SELECT users.id FROM tenant_a.users"
+	if got != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", got, expected)
+	}
+}
+
+func TestWalk_WriteQueries(t *testing.T) {
+	bans := New().Select("user_id").From("bans")
+	sessions := New().Select("1").From("sessions s").Where("s.user_id = users.id")
+
+	var froms []string
+	visit := func(b Builder) bool {
+		froms = append(froms, b.FromTable())
+		return true
+	}
+	Walk(NewUpdate().Table("users").Set("active = false").WhereIn("id", bans), visit)
+	Walk(NewDelete().From("users").WhereNotExists(sessions), visit)
+	Walk(NewInsert().Into("archive").Columns("id").Select(New().Select("id").From("users").WhereIn("id", bans)), visit)
+
+	want := []string{"bans", "sessions s", "users", "bans"}
+	if len(froms) != len(want) {
+		t.Fatalf("visited %v, want %v", froms, want)
+	}
+	for i := range want {
+		if froms[i] != want[i] {
+			t.Errorf("visit #%d = %q, want %q", i, froms[i], want[i])
+		}
+	}
+}
+
+func TestRewrite_Update(t *testing.T) {
+	sub := New().Select("user_id").From("bans").OrderBy("created_at")
+	q := NewUpdate().Table("users").Set("active = false").WhereIn("id", sub)
+
+	rewritten := Rewrite(q, func(b Builder) Builder {
+		return b.ClearOrderBy()
+	})
+
+	got, _, err := rewritten.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "UPDATE users SET active = false WHERE id IN (SELECT user_id FROM bans)"
+	if got != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", got, expected)
+	}
+
+	orig, _, _ := q.Build()
+	if orig != "UPDATE users SET active = false WHERE id IN (SELECT user_id FROM bans ORDER BY created_at)" {
+		t.Errorf("original was mutated: %s", orig)
+	}
+}
+
+func TestRewriteTables_WriteQueries(t *testing.T) {
+	partition := func(table string) string {
+		return table + "_2024"
+	}
+	bans := New().Select("user_id").From("bans")
+
+	tests := []struct {
+		q    Query
+		want string
+	}{
+		{
+			RewriteTables(NewUpdate().Table("users").Set("active = false").WhereIn("users.id", bans), partition),
+			"UPDATE users_2024 users SET active = false WHERE users.id IN (SELECT user_id FROM bans_2024 bans)",
+		},
+		{
+			RewriteTables(NewDelete().From("users u").WhereIn("u.id", bans), partition),
+			"DELETE FROM users_2024 u WHERE u.id IN (SELECT user_id FROM bans_2024 bans)",
+		},
+		{
+			RewriteTables(NewInsert().Into("banned").Columns("user_id").Select(bans), partition),
+			"INSERT INTO banned_2024 (user_id) SELECT user_id FROM bans_2024 bans",
+		},
+		{
+			RewriteTables(NewTruncate().Table("users", "bans"), partition),
+			"TRUNCATE users_2024, bans_2024",
+		},
+	}
+	for _, tt := range tests {
+		got, _, err := tt.q.Build()
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("SQL mismatch\n got: %s\nwant: %s", got, tt.want)
+		}
+	}
+}
+
+func TestRewriteTables_LateralRef(t *testing.T) {
+	b, err := Parse("SELECT * FROM users u LEFT JOIN LATERAL (SELECT 1 AS n) x ON true")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	got, _, err := RewriteTables(b, func(table string) string {
+		return "archive." + table
+	}).Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "SELECT * FROM archive.users u LEFT JOIN LATERAL (SELECT 1 AS n) x ON true"
+	if got != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", got, expected)
+	}
+}
+
+func TestRewriteTables_KeepsScopesAndSoftDelete(t *testing.T) {
+	RegisterSoftDelete("users", "deleted_at")
+	t.Cleanup(func() { RegisterSoftDelete("users", "") })
+
+	partition := func(table string) string {
+		return table + "_2024"
+	}
+	tenant := NewScope("tenant", "{table}.tenant_id = :tenant_id", Params{"tenant_id": 7}).ForTables("users", "orders")
+
+	got, _, err := RewriteTables(NewDelete().From("users").Where("id = 1").Scoped(tenant), partition).Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "UPDATE users_2024 users SET deleted_at = now() WHERE id = 1 AND deleted_at IS NULL AND users.tenant_id = :tenant_id"
+	if got != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", got, expected)
+	}
+
+	q := New().Select("u.id").
+		From("users u").
+		LeftJoin("orders o ON o.user_id = u.id").
+		Scoped(tenant)
+	twice := RewriteTables(RewriteTables(q, partition), func(table string) string {
+		return "archive." + table
+	})
+	got, _, err = twice.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected = "SELECT u.id FROM archive.users_2024 u" +
+		" LEFT JOIN archive.orders_2024 o ON o.user_id = u.id AND o.tenant_id = :tenant_id" +
+		" WHERE u.tenant_id = :tenant_id"
+	if got != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", got, expected)
+	}
+
+	got, _, err = RewriteTables(NewUpdate().Table("users").Set("active = false").All().Scoped(tenant), partition).Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected = "UPDATE users_2024 users SET active = false WHERE users.tenant_id = :tenant_id"
+	if got != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", got, expected)
+	}
+}
@@ -3,50 +3,87 @@ package squildx
 import "fmt"
 
 func (b *builder) Where(sql string, params ...Params) Builder {
+	return b.where("Where", "", sql, params)
+}
+
+// WhereTagged adds a WHERE clause like Where, tagged so that RemoveWhere can
+// take it out again.
+func (b *builder) WhereTagged(tag, sql string, params ...Params) Builder {
+	return b.where("WhereTagged", tag, sql, params)
+}
+
+func (b *builder) where(method, tag, sql string, params []Params) *builder {
 	cp := b.clone()
 	p, err := extractParams(params)
 	if err != nil {
-		cp.err = err
+		cp.failAdd("wheres", method, len(cp.wheres), sql, err)
 		return cp
 	}
 	parsed, prefix, err := parseParams(sql, p)
 	if err != nil {
-		cp.err = err
+		cp.failAdd("wheres", method, len(cp.wheres), sql, err)
 		return cp
 	}
 	if err := cp.setPrefix(prefix); err != nil {
-		cp.err = err
+		cp.failAdd("wheres", method, len(cp.wheres), sql, err)
 		return cp
 	}
-	cp.wheres = append(cp.wheres, paramClause{sql: sql, params: parsed})
+	cp.wheres = append(cp.wheres, paramClause{
+		sql:    sql,
+		params: parsed,
+		tag:    tag,
+		method: method,
+		index:  cp.callIndex("wheres", len(cp.wheres)),
+	})
+	return cp
+}
+
+// RemoveWhere removes the WHERE clauses added by WhereTagged with tag. An
+// empty tag removes nothing.
+func (b *builder) RemoveWhere(tag string) Builder {
+	cp := b.clone()
+	cp.wheres = withoutTag(cp.wheres, tag)
+	cp.resetPrefix()
 	return cp
 }
 
 func (b *builder) WhereExists(sub Builder) Builder {
 	cp := b.clone()
-	cp.wheres = append(cp.wheres, paramClause{subQuery: sub, subPrefix: "EXISTS"})
+	cp.wheres = append(cp.wheres, paramClause{
+		subQuery:  sub,
+		subPrefix: "EXISTS",
+		method:    "WhereExists",
+		index:     cp.callIndex("wheres", len(cp.wheres)),
+	})
 	return cp
 }
 
 func (b *builder) WhereNotExists(sub Builder) Builder {
 	cp := b.clone()
-	cp.wheres = append(cp.wheres, paramClause{subQuery: sub, subPrefix: "NOT EXISTS"})
+	cp.wheres = append(cp.wheres, paramClause{
+		subQuery:  sub,
+		subPrefix: "NOT EXISTS",
+		method:    "WhereNotExists",
+		index:     cp.callIndex("wheres", len(cp.wheres)),
+	})
 	return cp
 }
 
 func (b *builder) WhereIn(column string, sub Builder) Builder {
-	return addWhereInSubquery(b, column, "IN", sub)
+	return addWhereInSubquery(b, "WhereIn", column, "IN", sub)
 }
 
 func (b *builder) WhereNotIn(column string, sub Builder) Builder {
-	return addWhereInSubquery(b, column, "NOT IN", sub)
+	return addWhereInSubquery(b, "WhereNotIn", column, "NOT IN", sub)
 }
 
-func addWhereInSubquery(b *builder, column, keyword string, sub Builder) *builder {
+func addWhereInSubquery(b *builder, method, column, keyword string, sub Builder) *builder {
 	cp := b.clone()
 	cp.wheres = append(cp.wheres, paramClause{
 		subQuery:  sub,
 		subPrefix: fmt.Sprintf("%s %s", column, keyword),
+		method:    method,
+		index:     cp.callIndex("wheres", len(cp.wheres)),
 	})
 	return cp
 }
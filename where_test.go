@@ -356,3 +356,110 @@ func TestWhereInQualifiedColumn(t *testing.T) {
 		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
 	}
 }
+
+func TestRemoveWhere(t *testing.T) {
+	base := New().Select("id").
+		From("users").
+		WhereTagged("visibility", "active = :active", Params{"active": true}).
+		Where("age > :age", Params{"age": 18}).
+		WhereTagged("visibility", "hidden = false")
+
+	q, params, err := base.RemoveWhere("visibility").Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "SELECT id FROM users WHERE age > :age"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+	if _, ok := params["active"]; ok {
+		t.Error("params of removed clause should be dropped")
+	}
+
+	q, _, err = base.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected = "SELECT id FROM users WHERE active = :active AND age > :age AND hidden = false"
+	if q != expected {
+		t.Errorf("base builder was mutated\n got: %s\nwant: %s", q, expected)
+	}
+}
+
+func TestRemoveWhere_EmptyTag(t *testing.T) {
+	base := New().Select("id").
+		From("users").
+		Where("age > :age", Params{"age": 18}).
+		WhereTagged("visibility", "active = true")
+
+	q, _, err := base.RemoveWhere("").Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "SELECT id FROM users WHERE age > :age AND active = true"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+
+	_, _, err = NewUpdate().Table("users").
+		Set("name = :name", Params{"name": "Alice"}).
+		Where("id = :id", Params{"id": 1}).
+		RemoveWhere("").
+		Build()
+	if err != nil {
+		t.Errorf("RemoveWhere(\"\") should keep untagged update clauses, got: %v", err)
+	}
+
+	_, _, err = NewDelete().From("users").Where("id = :id", Params{"id": 1}).RemoveWhere("").Build()
+	if err != nil {
+		t.Errorf("RemoveWhere(\"\") should keep untagged delete clauses, got: %v", err)
+	}
+}
+
+func TestWhereTagged_Error(t *testing.T) {
+	_, _, err := New().Select("id").From("users").WhereTagged("t", "id = :id").Build()
+
+	var be *BuildError
+	if !errors.As(err, &be) || be.Method != "WhereTagged" {
+		t.Errorf("expected WhereTagged BuildError, got: %v", err)
+	}
+}
+
+func TestRemoveWhere_ResetsPrefix(t *testing.T) {
+	q, _, err := New().Select("id").
+		From("users").
+		WhereTagged("x", "a = @a", Params{"a": 1}).
+		RemoveWhere("x").
+		Where("b = :b", Params{"b": 2}).
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "SELECT id FROM users WHERE b = :b"
+	if q != expected {
+		t.Errorf("SQL mismatch\n got: %s\nwant: %s", q, expected)
+	}
+
+	_, _, err = New().Select("id").
+		From("users").
+		WhereTagged("x", "a = @a", Params{"a": 1}).
+		Where("c = @c", Params{"c": 3}).
+		RemoveWhere("x").
+		Where("b = :b", Params{"b": 2}).
+		Build()
+	if !errors.Is(err, ErrMixedPrefix) {
+		t.Errorf("expected ErrMixedPrefix from the remaining clause, got: %v", err)
+	}
+
+	_, _, err = NewUpdate().Table("users").
+		Set("name = :name", Params{"name": "Alice"}).
+		WhereTagged("x", "id = @id", Params{"id": 1}).
+		RemoveWhere("x").
+		Where("id = :id", Params{"id": 1}).
+		Build()
+	if !errors.Is(err, ErrMixedPrefix) {
+		t.Errorf("expected ErrMixedPrefix from the Set clause, got: %v", err)
+	}
+}